@@ -1,12 +1,32 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/cli"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
 	"github.com/OliveiraNt/k8s-manager/internal/tui"
 	tea "github.com/charmbracelet/bubbletea"
+	"os"
 )
 
 func main() {
-	m := tui.NewModel()
+	flag.Parse()
+	if dir := kubernetes.SnapshotDir(); dir != "" {
+		if err := kubernetes.OpenSnapshot(dir); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, "invalid snapshot:", err)
+			os.Exit(1)
+		}
+	}
+	// A command prints what the views show and exits, for scripts
+	if args := flag.Args(); len(args) > 0 {
+		os.Exit(cli.Run(args, os.Stdout, os.Stderr))
+	}
+	if err := tui.LoadConfig(); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "invalid configuration:", err)
+		os.Exit(1)
+	}
+	m := tui.NewTabs()
 
 	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
 		panic(err)
@@ -0,0 +1,360 @@
+// Package cli runs the subcommands that print what the UI shows, for scripts and CI. The pod
+// STATUS is the one of the pod view, and the exit code tells whether the command worked.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Exit codes of the subcommands
+const (
+	exitOK = 0
+	// exitError is a failed call to the cluster
+	exitError = 1
+	// exitUsage is a command line that doesn't parse
+	exitUsage = 2
+)
+
+// usageError is a command line that doesn't parse, the usage of the command is printed with it
+type usageError struct {
+	error
+}
+
+// command is a subcommand and how to call it
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, out io.Writer) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"pods", "pods [-n namespace | -A] [-l selector] [-o table|wide|json|yaml] [--context name]", runPods},
+		{"contexts", "contexts [-o table|wide|json|yaml]", runContexts},
+		{"namespaces", "namespaces [-o table|wide|json|yaml] [--context name]", runNamespaces},
+		{"logs", "logs pod [-n namespace] [-c container] [--since 10m] [--tail lines] [-f] [--context name]", runLogs},
+		{"snapshot", "snapshot save dir [-n namespace] [--tail lines] [--context name]", runSnapshot},
+	}
+}
+
+// Run Run a subcommand, printing its errors to stderr, and get the exit code
+func Run(args []string, stdout io.Writer, stderr io.Writer) (code int) {
+	// The kubernetes package panics on a kubeconfig it can't read, a script wants an exit code
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = fmt.Fprintln(stderr, "error:", r)
+			code = exitError
+		}
+	}()
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stdout)
+		return exitOK
+	}
+	for _, c := range commands {
+		if c.name != args[0] {
+			continue
+		}
+		err := c.run(args[1:], stdout)
+		var ue usageError
+		switch {
+		case err == nil:
+			return exitOK
+		case errors.Is(err, flag.ErrHelp):
+			_, _ = fmt.Fprintln(stdout, "usage: k8s-manager "+c.usage)
+			return exitOK
+		case errors.As(err, &ue):
+			_, _ = fmt.Fprintln(stderr, "error:", err)
+			_, _ = fmt.Fprintln(stderr, "usage: k8s-manager "+c.usage)
+			return exitUsage
+		default:
+			_, _ = fmt.Fprintln(stderr, "error:", err)
+			return exitError
+		}
+	}
+	_, _ = fmt.Fprintf(stderr, "error: unknown command %q\n", args[0])
+	usage(stderr)
+	return exitUsage
+}
+
+func usage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "usage: k8s-manager [--kubeconfig file | --snapshot dir] [command]")
+	_, _ = fmt.Fprintln(w, "\nWithout a command the user interface starts. Commands:")
+	for _, c := range commands {
+		_, _ = fmt.Fprintln(w, "  k8s-manager "+c.usage)
+	}
+}
+
+// options are the flags the commands share
+type options struct {
+	context   string
+	namespace string
+	all       bool
+	output    string
+}
+
+func newFlagSet(name string, o *options) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&o.context, "context", "", "kube context")
+	fs.StringVar(&o.namespace, "n", "", "namespace")
+	fs.StringVar(&o.namespace, "namespace", "", "namespace")
+	fs.StringVar(&o.output, "o", "table", "output format")
+	fs.StringVar(&o.output, "output", "table", "output format")
+	return fs
+}
+
+// parse Parse the flags wherever they are on the line, and get the other arguments
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var rest []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, usageError{err}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return rest, nil
+		}
+		rest = append(rest, args[0])
+		args = args[1:]
+	}
+}
+
+// apply Check the shared flags and send the calls to the chosen context
+func (o options) apply() error {
+	if !slices.Contains(Outputs, o.output) {
+		return usageError{fmt.Errorf("unknown output %q, use one of %s", o.output, strings.Join(Outputs, ", "))}
+	}
+	if o.all && o.namespace != "" {
+		return usageError{errors.New("-A and -n don't go together")}
+	}
+	if o.context != "" {
+		if _, ok := kubernetes.ListContexts()[o.context]; !ok {
+			return fmt.Errorf("no context %q in the kubeconfig", o.context)
+		}
+		kubernetes.Use(o.context)
+	}
+	return nil
+}
+
+// ns Get the namespace to list, the one of the context unless -n or -A says otherwise
+func (o options) ns() string {
+	switch {
+	case o.all:
+		return ""
+	case o.namespace != "":
+		return o.namespace
+	}
+	name, ns, _ := kubernetes.GetCurrent()
+	if o.context != "" && o.context != name {
+		ns = kubernetes.ListContexts()[o.context].Namespace
+	}
+	if ns == "" {
+		ns = "default"
+	}
+	return ns
+}
+
+func runPods(args []string, out io.Writer) error {
+	var o options
+	var selector string
+	fs := newFlagSet("pods", &o)
+	fs.BoolVar(&o.all, "A", false, "all namespaces")
+	fs.BoolVar(&o.all, "all-namespaces", false, "all namespaces")
+	fs.StringVar(&selector, "l", "", "label selector")
+	fs.StringVar(&selector, "selector", "", "label selector")
+	rest, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usageError{fmt.Errorf("unexpected %s", strings.Join(rest, " "))}
+	}
+	if err := o.apply(); err != nil {
+		return err
+	}
+
+	ns := o.ns()
+	pt, err := kubernetes.GetPodTable(context.Background(), ns, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	var t table
+	if ns == "" {
+		t.column("Namespace", false)
+	}
+	for _, c := range pt.ColumnDefinitions {
+		t.column(c.Name, c.Priority > 0)
+	}
+	for _, r := range pt.Rows {
+		var row []string
+		if ns == "" {
+			namespace := ""
+			if p, ok := kubernetes.TablePod(r); ok {
+				namespace = p.Namespace
+			}
+			row = append(row, namespace)
+		}
+		for i := range pt.ColumnDefinitions {
+			cell := ""
+			if i < len(r.Cells) {
+				cell = kubernetes.TableCell(r.Cells[i])
+			}
+			row = append(row, cell)
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t.print(out, o.output)
+}
+
+func runContexts(args []string, out io.Writer) error {
+	var o options
+	fs := newFlagSet("contexts", &o)
+	rest, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usageError{fmt.Errorf("unexpected %s", strings.Join(rest, " "))}
+	}
+	if err := o.apply(); err != nil {
+		return err
+	}
+
+	current, _, _ := kubernetes.GetCurrent()
+	ctxs := kubernetes.ListContexts()
+	names := make([]string, 0, len(ctxs))
+	for n := range ctxs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	var t table
+	t.column("Current", false)
+	t.column("Name", false)
+	t.column("Cluster", false)
+	t.column("Namespace", false)
+	t.column("User", true)
+	for _, n := range names {
+		mark := ""
+		if n == current {
+			mark = "*"
+		}
+		c := ctxs[n]
+		t.rows = append(t.rows, []string{mark, n, c.Cluster, c.Namespace, c.AuthInfo})
+	}
+	return t.print(out, o.output)
+}
+
+func runNamespaces(args []string, out io.Writer) error {
+	var o options
+	fs := newFlagSet("namespaces", &o)
+	rest, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usageError{fmt.Errorf("unexpected %s", strings.Join(rest, " "))}
+	}
+	if err := o.apply(); err != nil {
+		return err
+	}
+
+	nss, err := kubernetes.GetNamespaces(context.Background())
+	if err != nil {
+		return err
+	}
+	var t table
+	t.column("Name", false)
+	t.column("Status", false)
+	t.column("Age", false)
+	for _, ns := range nss {
+		t.rows = append(t.rows, []string{ns.Name, string(ns.Status.Phase), kubernetes.ColumnHelperAge(ns.CreationTimestamp)})
+	}
+	return t.print(out, o.output)
+}
+
+func runLogs(args []string, out io.Writer) error {
+	var o options
+	lo := kubernetes.LogOptions{Tail: -1}
+	fs := newFlagSet("logs", &o)
+	fs.StringVar(&lo.Container, "c", "", "container")
+	fs.StringVar(&lo.Container, "container", "", "container")
+	fs.DurationVar(&lo.Since, "since", 0, "only the logs of the last duration")
+	fs.Int64Var(&lo.Tail, "tail", -1, "only the last lines")
+	fs.BoolVar(&lo.Follow, "f", false, "follow")
+	fs.BoolVar(&lo.Follow, "follow", false, "follow")
+	rest, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return usageError{errors.New("logs takes one pod name")}
+	}
+	if err := o.apply(); err != nil {
+		return err
+	}
+	if lo.Since < 0 {
+		return usageError{fmt.Errorf("--since %s is negative", lo.Since)}
+	}
+
+	// Following ends with ctrl+c, which is not an error
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	err = kubernetes.WritePodLogs(ctx, o.ns(), rest[0], lo, out)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func runSnapshot(args []string, out io.Writer) error {
+	var o options
+	var tail int64
+	fs := newFlagSet("snapshot", &o)
+	fs.Int64Var(&tail, "tail", 1000, "last lines of each container log, -1 for all")
+	rest, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 2 || rest[0] != "save" {
+		return usageError{errors.New("snapshot save takes one directory")}
+	}
+	// The shared flags give -o, a snapshot is written as JSON whatever it says
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "o" || f.Name == "output" {
+			err = usageError{fmt.Errorf("snapshot save writes files, it takes no -%s", f.Name)}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if err := o.apply(); err != nil {
+		return err
+	}
+
+	info, err := kubernetes.SaveSnapshot(context.Background(), rest[1], o.ns(), tail)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "saved %s/%s to %s, browse it with k8s-manager --snapshot %s\n", info.Context, info.Namespace, rest[1], rest[1])
+	for _, s := range info.Skipped {
+		_, _ = fmt.Fprintf(out, "skipped %s, the user may not list it\n", strings.TrimSuffix(s, ".json"))
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Outputs are the formats -o takes
+var Outputs = []string{"table", "wide", "json", "yaml"}
+
+// table is what a command prints, the same columns the UI shows
+type table struct {
+	columns []string
+	// wide marks the columns only -o wide shows
+	wide []bool
+	rows [][]string
+}
+
+func (t *table) column(name string, wide bool) {
+	t.columns = append(t.columns, name)
+	t.wide = append(t.wide, wide)
+}
+
+// print Write the table in an output format, json and yaml carry every column
+func (t table) print(w io.Writer, output string) error {
+	switch output {
+	case "json", "yaml":
+		objects := make([]map[string]string, 0, len(t.rows))
+		for _, r := range t.rows {
+			o := map[string]string{}
+			for i, c := range t.columns {
+				o[fieldName(c)] = r[i]
+			}
+			objects = append(objects, o)
+		}
+		var out []byte
+		var err error
+		if output == "json" {
+			out, err = json.MarshalIndent(objects, "", "  ")
+			out = append(out, '\n')
+		} else {
+			out, err = yaml.Marshal(objects)
+		}
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		var idx []int
+		var header []string
+		for i, c := range t.columns {
+			if !t.wide[i] || output == "wide" {
+				idx = append(idx, i)
+				header = append(header, strings.ToUpper(c))
+			}
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, r := range t.rows {
+			cells := make([]string, 0, len(idx))
+			for _, i := range idx {
+				cells = append(cells, r[i])
+			}
+			_, _ = fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+// fieldName Get the json field of a column, like nominatedNode for "Nominated Node"
+func fieldName(column string) string {
+	words := strings.FieldsFunc(column, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	for i, w := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(w)
+		} else {
+			words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+		}
+	}
+	return strings.Join(words, "")
+}
@@ -0,0 +1,444 @@
+// Package config loads the user configuration from $XDG_CONFIG_HOME/k8s-manager/config.yaml,
+// falling back to ~/.config when XDG_CONFIG_HOME is not set. A missing file means defaults.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/homedir"
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the user configuration
+type Config struct {
+	// Keys maps a view to its actions and the keys bound to them, "global" holds quit
+	Keys     map[string]map[string][]string `json:"keys,omitempty"`
+	Theme    Theme                          `json:"theme,omitempty"`
+	Defaults Defaults                       `json:"defaults,omitempty"`
+	Refresh  Refresh                        `json:"refresh,omitempty"`
+	Logs     Logs                           `json:"logs,omitempty"`
+	Table    Table                          `json:"table,omitempty"`
+	// Aliases are commands of the command line, like pp: "pods -n payments"
+	Aliases map[string]string `json:"aliases,omitempty"`
+	// Contexts are safety profiles, the first one matching the current context applies
+	Contexts []Profile `json:"contexts,omitempty"`
+	// Plugins are user commands run on the selected object
+	Plugins []Plugin `json:"plugins,omitempty"`
+}
+
+// Theme picks a built-in skin, the colors override single entries of it
+type Theme struct {
+	Name               string `json:"name,omitempty"`
+	Accent             string `json:"accent,omitempty"`
+	Error              string `json:"error,omitempty"`
+	Warning            string `json:"warning,omitempty"`
+	OK                 string `json:"ok,omitempty"`
+	SelectedBackground string `json:"selectedBackground,omitempty"`
+	BadgeForeground    string `json:"badgeForeground,omitempty"`
+}
+
+// Defaults is what the UI opens with
+type Defaults struct {
+	// View is the first view shown, see Views
+	View string `json:"view,omitempty"`
+	// Namespace overrides the namespace of the current context
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Refresh holds polling intervals
+type Refresh struct {
+	Metrics metav1.Duration `json:"metrics,omitempty"`
+}
+
+// Logs sizes the log view
+type Logs struct {
+	// Tail is how many past lines a log stream starts with
+	Tail int64 `json:"tail,omitempty"`
+	// Buffer is how many lines the log view keeps
+	Buffer int `json:"buffer,omitempty"`
+}
+
+// Table sizes the tables
+type Table struct {
+	// MaxColumnWidth caps every column, zero keeps the width each view picks
+	MaxColumnWidth int `json:"maxColumnWidth,omitempty"`
+}
+
+// Profile changes how the UI behaves on the contexts it matches
+type Profile struct {
+	// Name matches one context, Match is a regular expression on the context name, set one of them
+	Name  string `json:"name,omitempty"`
+	Match string `json:"match,omitempty"`
+	// Color paints the header, Label is shown as a banner, like "PROD"
+	Color string `json:"color,omitempty"`
+	Label string `json:"label,omitempty"`
+	// ReadOnly disables every action that changes the cluster
+	ReadOnly bool `json:"readOnly,omitempty"`
+	// ConfirmByName makes destructive actions ask for the name of the target instead of y/n
+	ConfirmByName bool `json:"confirmByName,omitempty"`
+}
+
+// Matches Tell whether the profile applies to a context
+func (p Profile) Matches(context string) bool {
+	if p.Name != "" {
+		return p.Name == context
+	}
+	re, err := regexp.Compile("^(?:" + p.Match + ")$")
+	return err == nil && re.MatchString(context)
+}
+
+// ProfileFor Get the profile of a context, the zero profile when none matches
+func ProfileFor(context string) Profile {
+	for _, p := range Current.Contexts {
+		if p.Matches(context) {
+			return p
+		}
+	}
+	return Profile{}
+}
+
+// Plugin is a shell command bound to a key on the views of some kinds
+type Plugin struct {
+	// Scopes are the kinds it applies to by their plural name, like pods or deployments, or all
+	Scopes      []string `json:"scopes"`
+	Key         string   `json:"key"`
+	Description string   `json:"description"`
+	// Command is a template run by sh, with {{.Namespace}}, {{.Name}}, {{.Kind}}, {{.Context}} and {{.Container}},
+	// quote makes a value a single shell word, like {{quote .Context}}
+	Command string `json:"command"`
+	// Background runs the command without leaving the UI, otherwise it takes over the terminal
+	Background bool `json:"background,omitempty"`
+	// Mutating marks a command that changes the cluster, read-only contexts refuse it
+	Mutating bool `json:"mutating,omitempty"`
+}
+
+// PluginVars are the values a plugin command is rendered with
+type PluginVars struct {
+	Namespace string
+	Name      string
+	Kind      string
+	Context   string
+	// Container is the default container of a pod, empty for other kinds
+	Container string
+}
+
+// ScopeKinds maps the plugin scopes to the kind they stand for
+var ScopeKinds = map[string]string{
+	"pods":                   "Pod",
+	"deployments":            "Deployment",
+	"statefulsets":           "StatefulSet",
+	"daemonsets":             "DaemonSet",
+	"replicasets":            "ReplicaSet",
+	"services":               "Service",
+	"nodes":                  "Node",
+	"jobs":                   "Job",
+	"cronjobs":               "CronJob",
+	"ingresses":              "Ingress",
+	"configmaps":             "ConfigMap",
+	"secrets":                "Secret",
+	"persistentvolumeclaims": "PersistentVolumeClaim",
+	"persistentvolumes":      "PersistentVolume",
+}
+
+// Applies Tell whether the plugin runs on objects of a kind
+func (p Plugin) Applies(kind string) bool {
+	for _, s := range p.Scopes {
+		if s == "all" || ScopeKinds[s] == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// pluginFuncs are the functions plugin commands can call
+var pluginFuncs = template.FuncMap{"quote": shellQuote}
+
+// shellQuote Quote a value for sh, context names come from the kubeconfig and may hold spaces or $()
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Render Get the shell command of the plugin for an object
+func (p Plugin) Render(v PluginVars) (string, error) {
+	t, err := template.New(p.Key).Option("missingkey=error").Funcs(pluginFuncs).Parse(p.Command)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, v); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Views are the names the default view can take
+var Views = []string{"pods", "context", "namespace", "events", "nodes", "services", "configmaps", "secrets", "jobs", "ingresses", "storage", "deployments", "statefulsets", "daemonsets"}
+
+// Themes are the built-in skins
+var Themes = map[string]Theme{
+	"default": {
+		Accent:             "#FF7900",
+		Error:              "#FF3B30",
+		Warning:            "#FFCC00",
+		OK:                 "#34C759",
+		SelectedBackground: "#000",
+		BadgeForeground:    "#FFF",
+	},
+	"light": {
+		Accent:             "#C25700",
+		Error:              "#D70015",
+		Warning:            "#B25000",
+		OK:                 "#248A3D",
+		SelectedBackground: "#E5E5EA",
+		BadgeForeground:    "#FFF",
+	},
+	"mono": {
+		Accent:             "15",
+		Error:              "9",
+		Warning:            "11",
+		OK:                 "10",
+		SelectedBackground: "0",
+		BadgeForeground:    "15",
+	},
+}
+
+var colorRe = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Current is the configuration in use, the defaults when Err is set
+var Current, Path, Err = load()
+
+// Default Get the configuration used when there is no file
+func Default() Config {
+	return Config{
+		Theme:    Themes["default"],
+		Defaults: Defaults{View: "pods"},
+		Refresh:  Refresh{Metrics: metav1.Duration{Duration: 10 * time.Second}},
+		Logs:     Logs{Tail: 50, Buffer: 5000},
+	}
+}
+
+// path Get where the configuration file lives
+func path() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		dir = filepath.Join(homedir.HomeDir(), ".config")
+	}
+	return filepath.Join(dir, "k8s-manager", "config.yaml")
+}
+
+func load() (Config, string, error) {
+	p := path()
+	raw, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return Default(), p, nil
+	}
+	if err != nil {
+		return Default(), p, err
+	}
+	c, err := Parse(raw)
+	if err != nil {
+		return Default(), p, fmt.Errorf("%s: %w", p, err)
+	}
+	return c, p, nil
+}
+
+// Parse Read a configuration over the defaults and validate it
+func Parse(raw []byte) (Config, error) {
+	var c Config
+	if err := yaml.UnmarshalStrict(raw, &c); err != nil {
+		return Default(), err
+	}
+	c = merge(Default(), c)
+	if err := c.Validate(); err != nil {
+		return Default(), err
+	}
+	return c, nil
+}
+
+// merge Fill what the file left out with the defaults, theme colors fall back to the named theme
+func merge(d Config, c Config) Config {
+	name := c.Theme.Name
+	if name == "" {
+		name = "default"
+	}
+	base, ok := Themes[name]
+	if !ok {
+		// Validate reports the unknown name
+		base = d.Theme
+	}
+	t := c.Theme
+	t.Accent = or(t.Accent, base.Accent)
+	t.Error = or(t.Error, base.Error)
+	t.Warning = or(t.Warning, base.Warning)
+	t.OK = or(t.OK, base.OK)
+	t.SelectedBackground = or(t.SelectedBackground, base.SelectedBackground)
+	t.BadgeForeground = or(t.BadgeForeground, base.BadgeForeground)
+	c.Theme = t
+
+	c.Defaults.View = or(c.Defaults.View, d.Defaults.View)
+	if c.Refresh.Metrics.Duration == 0 {
+		c.Refresh.Metrics = d.Refresh.Metrics
+	}
+	if c.Logs.Tail == 0 {
+		c.Logs.Tail = d.Logs.Tail
+	}
+	if c.Logs.Buffer == 0 {
+		c.Logs.Buffer = d.Logs.Buffer
+	}
+	return c
+}
+
+func or(v string, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+// Validate Check the values, every problem is reported with the path of the setting
+func (c Config) Validate() error {
+	var errs []error
+	fail := func(field string, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+
+	for _, view := range sortedKeys(c.Keys) {
+		for _, action := range sortedKeys(c.Keys[view]) {
+			ks := c.Keys[view][action]
+			if len(ks) == 0 {
+				fail("keys."+view+"."+action, "needs at least one key")
+			}
+			for _, k := range ks {
+				if strings.TrimSpace(k) == "" && k != " " {
+					fail("keys."+view+"."+action, "empty key")
+				}
+			}
+		}
+	}
+
+	if c.Theme.Name != "" {
+		if _, ok := Themes[c.Theme.Name]; !ok {
+			fail("theme.name", "unknown theme %q, use one of %s", c.Theme.Name, strings.Join(sortedKeys(Themes), ", "))
+		}
+	}
+	for field, v := range map[string]string{
+		"theme.accent":             c.Theme.Accent,
+		"theme.error":              c.Theme.Error,
+		"theme.warning":            c.Theme.Warning,
+		"theme.ok":                 c.Theme.OK,
+		"theme.selectedBackground": c.Theme.SelectedBackground,
+		"theme.badgeForeground":    c.Theme.BadgeForeground,
+	} {
+		if !validColor(v) {
+			fail(field, "%q is not a #RGB, #RRGGBB or 0-255 color", v)
+		}
+	}
+
+	if !contains(Views, c.Defaults.View) {
+		fail("defaults.view", "unknown view %q, use one of %s", c.Defaults.View, strings.Join(Views, ", "))
+	}
+	if c.Refresh.Metrics.Duration < time.Second {
+		fail("refresh.metrics", "%s is shorter than 1s", c.Refresh.Metrics.Duration)
+	}
+	if c.Logs.Tail < 0 {
+		fail("logs.tail", "must not be negative")
+	}
+	if c.Logs.Buffer < 0 {
+		fail("logs.buffer", "must not be negative")
+	}
+	if c.Table.MaxColumnWidth < 0 {
+		fail("table.maxColumnWidth", "must not be negative")
+	}
+	for _, a := range sortedKeys(c.Aliases) {
+		if a == "" || strings.ContainsAny(a, " \t") {
+			fail("aliases."+a, "an alias is a single word")
+		}
+		if strings.TrimSpace(c.Aliases[a]) == "" {
+			fail("aliases."+a, "needs a command")
+		}
+	}
+	for i, p := range c.Contexts {
+		field := fmt.Sprintf("contexts[%d]", i)
+		if (p.Name == "") == (p.Match == "") {
+			fail(field, "set either name or match")
+		}
+		if p.Match != "" {
+			if _, err := regexp.Compile(p.Match); err != nil {
+				fail(field+".match", "%v", err)
+			}
+		}
+		if p.Color != "" && !validColor(p.Color) {
+			fail(field+".color", "%q is not a #RGB, #RRGGBB or 0-255 color", p.Color)
+		}
+	}
+	for i, p := range c.Plugins {
+		field := fmt.Sprintf("plugins[%d]", i)
+		if len(p.Scopes) == 0 {
+			fail(field+".scopes", "needs at least one kind")
+		}
+		for _, s := range p.Scopes {
+			if _, ok := ScopeKinds[s]; !ok && s != "all" {
+				fail(field+".scopes", "unknown kind %q, use all or one of %s", s, strings.Join(sortedKeys(ScopeKinds), ", "))
+			}
+		}
+		if p.Key == "" {
+			fail(field+".key", "needs a key")
+		}
+		if p.Description == "" {
+			fail(field+".description", "needs a description for the help")
+		}
+		if strings.TrimSpace(p.Command) == "" {
+			fail(field+".command", "needs a command")
+		} else if _, err := p.Render(PluginVars{}); err != nil {
+			fail(field+".command", "%v", err)
+		}
+	}
+
+	// Keep the messages in a stable order, the theme colors come from a map
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errors.Join(errs...)
+}
+
+func validColor(v string) bool {
+	if colorRe.MatchString(v) {
+		return true
+	}
+	n, err := strconv.Atoi(v)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// ColumnWidth Get the column width cap, the view default unless the user set one
+func ColumnWidth(def int) int {
+	if Current.Table.MaxColumnWidth > 0 {
+		return Current.Table.MaxColumnWidth
+	}
+	return def
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
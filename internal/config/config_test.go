@@ -0,0 +1,133 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	light := Themes["light"]
+
+	// want is checked on the fields it sets, the zero fields keep the defaults
+	tests := []struct {
+		name string
+		yaml string
+		want func(c Config) bool
+	}{
+		{
+			name: "empty file",
+			yaml: "",
+			want: func(c Config) bool { return reflect.DeepEqual(c, Default()) },
+		},
+		{
+			name: "theme colors fall back to the named theme",
+			yaml: "theme:\n  name: light\n  accent: \"#123\"\n",
+			want: func(c Config) bool {
+				return c.Theme.Accent == "#123" && c.Theme.Error == light.Error && c.Theme.OK == light.OK
+			},
+		},
+		{
+			name: "theme colors fall back to the default theme",
+			yaml: "theme:\n  warning: \"214\"\n",
+			want: func(c Config) bool {
+				return c.Theme.Warning == "214" && c.Theme.Accent == Themes["default"].Accent
+			},
+		},
+		{
+			name: "unset values keep the defaults",
+			yaml: "logs:\n  tail: 200\n",
+			want: func(c Config) bool {
+				return c.Logs.Tail == 200 && c.Logs.Buffer == Default().Logs.Buffer &&
+					c.Refresh.Metrics.Duration == 10*time.Second && c.Defaults.View == "pods"
+			},
+		},
+		{
+			name: "space is a key",
+			yaml: "keys:\n  pods:\n    mark: [\" \"]\n",
+			want: func(c Config) bool { return reflect.DeepEqual(c.Keys["pods"]["mark"], []string{" "}) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Parse([]byte(tt.yaml))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.want(c) {
+				t.Errorf("Parse = %+v", c)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	// want lists fragments of the error, one per problem in the sorted order they are reported in
+	tests := []struct {
+		name string
+		yaml string
+		want []string
+	}{
+		{"unknown field", "colour: red\n", []string{`unknown field "colour"`}},
+		{"unknown theme", "theme:\n  name: neon\n", []string{`theme.name: unknown theme "neon", use one of default, light, mono`}},
+		{"bad color", "theme:\n  accent: red\n  ok: \"256\"\n", []string{`theme.accent: "red" is not`, `theme.ok: "256" is not`}},
+		{"action without keys", "keys:\n  pods:\n    logs: []\n", []string{"keys.pods.logs: needs at least one key"}},
+		{"empty key", "keys:\n  pods:\n    logs: [\"\"]\n", []string{"keys.pods.logs: empty key"}},
+		{"unknown default view", "defaults:\n  view: pod\n", []string{`defaults.view: unknown view "pod"`}},
+		{"metrics too often", "refresh:\n  metrics: 500ms\n", []string{"refresh.metrics: 500ms is shorter than 1s"}},
+		{"negative sizes", "logs:\n  buffer: -1\ntable:\n  maxColumnWidth: -5\n", []string{"logs.buffer: must not be negative", "table.maxColumnWidth: must not be negative"}},
+		{"alias of two words", "aliases:\n  \"p p\": pods\n", []string{"aliases.p p: an alias is a single word"}},
+		{"profile with name and match", "contexts:\n- name: prod\n  match: prod-.*\n", []string{"contexts[0]: set either name or match"}},
+		{"profile with a bad match", "contexts:\n- match: \"prod-(\"\n", []string{"contexts[0].match: error parsing regexp"}},
+		{
+			name: "plugin problems",
+			yaml: "plugins:\n- scopes: [pod]\n  key: ctrl+l\n  command: \"stern {{.Nam}}\"\n",
+			want: []string{"can't evaluate field Nam in type config.PluginVars", "plugins[0].description: needs a description", `plugins[0].scopes: unknown kind "pod"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Parse([]byte(tt.yaml))
+			if err == nil {
+				t.Fatalf("Parse = %+v, want an error", c)
+			}
+			if !reflect.DeepEqual(c, Default()) {
+				t.Errorf("Parse = %+v on error, want the defaults", c)
+			}
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) != len(tt.want) {
+				t.Fatalf("got %d problems, want %d: %v", len(lines), len(tt.want), err)
+			}
+			for i, w := range tt.want {
+				if !strings.Contains(lines[i], w) {
+					t.Errorf("problem %d = %q, want it to hold %q", i, lines[i], w)
+				}
+			}
+		})
+	}
+}
+
+func TestPluginRender(t *testing.T) {
+	p := Plugin{Key: "Y", Command: "kubectl get {{.Kind}} {{quote .Name}} --context {{quote .Context}}"}
+	tests := []struct {
+		context string
+		want    string
+	}{
+		{"prod", `kubectl get Pod 'api' --context 'prod'`},
+		{"prod admin", `kubectl get Pod 'api' --context 'prod admin'`},
+		{"$(rm -rf ~); x", `kubectl get Pod 'api' --context '$(rm -rf ~); x'`},
+		{"it's", `kubectl get Pod 'api' --context 'it'\''s'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.context, func(t *testing.T) {
+			got, err := p.Render(PluginVars{Kind: "Pod", Name: "api", Context: tt.context})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Render = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
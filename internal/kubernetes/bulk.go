@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// BulkResult is the outcome of an action on one pod
+type BulkResult struct {
+	Namespace string
+	Pod       string
+	Output    string
+	Err       error
+}
+
+// DeletePod Delete a pod, controllers recreate it which makes it a restart
+func DeletePod(ctx context.Context, namespace string, name string) error {
+	if err := writable(ctx); err != nil {
+		return err
+	}
+	cs := getClientSet(ctx)
+	return cs.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// ExecPod Run a command in the default container of a pod and collect its output
+func ExecPod(ctx context.Context, namespace string, name string, command []string) (string, error) {
+	if err := writable(ctx); err != nil {
+		return "", err
+	}
+	cs := getClientSet(ctx)
+	p, err := cs.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	container := DefaultContainer(*p)
+
+	req := cs.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	exec, err := remotecommand.NewSPDYExecutor(getRestConfig(ctx), "POST", req.URL())
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &out, Stderr: &out})
+	return strings.TrimSpace(out.String()), err
+}
+
+// RunBulk Run an action on every pod with at most limit running at once, the
+// results keep the order of the pods
+func RunBulk(ctx context.Context, pds []v1.Pod, limit int, action func(ctx context.Context, p v1.Pod) (string, error)) []BulkResult {
+	results := make([]BulkResult, len(pds))
+	sem := make(chan struct{}, max(limit, 1))
+	var wg sync.WaitGroup
+	for i, p := range pds {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			out, err := action(ctx, p)
+			results[i] = BulkResult{Namespace: p.Namespace, Pod: p.Name, Output: out, Err: err}
+		}()
+	}
+	wg.Wait()
+	return results
+}
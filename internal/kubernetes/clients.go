@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// inUse is the kube context calls go to when their context.Context is bound to none,
+// empty for the current context of the kubeconfig
+var inUse atomic.Value
+
+// clients keeps one client per kube context, tabs on the same context share it
+var (
+	clientsMu      sync.Mutex
+	restConfigs    = map[string]*rest.Config{}
+	clients        = map[string]*kubernetes.Clientset{}
+	metricsClients = map[string]*versioned.Clientset{}
+)
+
+type boundKey struct{}
+
+// Use Send the calls to a kube context, the one of the tab shown
+func Use(name string) {
+	inUse.Store(name)
+}
+
+// InUse Get the kube context calls go to
+func InUse() string {
+	name, _ := inUse.Load().(string)
+	return name
+}
+
+// Bind Tie a context to the kube context in use, calls made with it later, like a drain in the
+// background, stay on that cluster after another tab is shown. A bound context keeps its cluster
+func Bind(ctx context.Context) context.Context {
+	return context.WithValue(ctx, boundKey{}, contextOf(ctx))
+}
+
+// contextOf Get the kube context a call goes to
+func contextOf(ctx context.Context) string {
+	if name, ok := ctx.Value(boundKey{}).(string); ok {
+		return name
+	}
+	return InUse()
+}
+
+// resolve Get the kubeconfig context of a name, a context name or the cluster of a single context.
+// A cluster shared by several contexts is left as it is, the credentials of a context are never guessed
+func resolve(name string) string {
+	contexts := ListContexts()
+	if _, ok := contexts[name]; ok || name == "" {
+		return name
+	}
+	var matches []string
+	for n, c := range contexts {
+		if c.Cluster == name {
+			matches = append(matches, n)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0]
+	}
+	return name
+}
+
+// getRestConfig Get the REST config of the kube context a call goes to
+func getRestConfig(ctx context.Context) *rest.Config {
+	name := contextOf(ctx)
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	if rc, ok := restConfigs[name]; ok {
+		return rc
+	}
+	rc, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: *kubeConfig},
+		&clientcmd.ConfigOverrides{CurrentContext: resolve(name)},
+	).ClientConfig()
+	if err != nil {
+		panic(err)
+	}
+	// The current context of the kubeconfig can change, only named contexts are kept
+	if name != "" {
+		restConfigs[name] = rc
+	}
+	return rc
+}
+
+// getClientSet Get the client of the kube context a call goes to, the one of the snapshot when one is open
+func getClientSet(ctx context.Context) kubernetes.Interface {
+	if offline != nil {
+		return offline.client
+	}
+	rc := getRestConfig(ctx)
+	name := contextOf(ctx)
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	if cs, ok := clients[name]; ok && name != "" {
+		return cs
+	}
+	cs, err := kubernetes.NewForConfig(rc)
+	if err != nil {
+		panic(err)
+	}
+	if name != "" {
+		clients[name] = cs
+	}
+	return cs
+}
+
+// getMetricsClientSet Get the metrics.k8s.io client of the kube context a call goes to
+func getMetricsClientSet(ctx context.Context) (versioned.Interface, error) {
+	rc := getRestConfig(ctx)
+	name := contextOf(ctx)
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	if cs, ok := metricsClients[name]; ok && name != "" {
+		return cs, nil
+	}
+	cs, err := versioned.NewForConfig(rc)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		metricsClients[name] = cs
+	}
+	return cs, nil
+}
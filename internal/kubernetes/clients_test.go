@@ -0,0 +1,52 @@
+package kubernetes
+
+import (
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestResolve(t *testing.T) {
+	config := api.NewConfig()
+	for name, cluster := range map[string]string{
+		"prod-admin":    "prod",
+		"prod-readonly": "prod",
+		"staging":       "staging-eu",
+		"dev":           "dev-cluster",
+		"dev-cluster":   "other",
+	} {
+		c := api.NewContext()
+		c.Cluster = cluster
+		config.Contexts[name] = c
+	}
+	path := filepath.Join(t.TempDir(), "config")
+	if err := clientcmd.WriteToFile(*config, path); err != nil {
+		t.Fatal(err)
+	}
+	old := *kubeConfig
+	*kubeConfig = path
+	t.Cleanup(func() { *kubeConfig = old })
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"", ""},
+		{"prod-readonly", "prod-readonly"},
+		{"staging-eu", "staging"},
+		{"dev-cluster", "dev-cluster"},
+		{"prod", "prod"},
+		{"unknown", "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 10 {
+				if got := resolve(tt.name); got != tt.want {
+					t.Fatalf("resolve(%q) = %q, want %q", tt.name, got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"k8s.io/apimachinery/pkg/types"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetConfigMaps Get config maps (use namespace)
+func GetConfigMaps(ctx context.Context, namespace string) ([]v1.ConfigMap, error) {
+	cs := getClientSet(ctx)
+
+	cms, err := cs.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return cms.Items, nil
+}
+
+// GetSecrets Get secrets (use namespace)
+func GetSecrets(ctx context.Context, namespace string) ([]v1.Secret, error) {
+	cs := getClientSet(ctx)
+
+	scs, err := cs.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return scs.Items, nil
+}
+
+// PatchConfigMapKey Set a single key of a config map
+func PatchConfigMapKey(ctx context.Context, namespace string, name string, key string, value string) error {
+	if err := writable(ctx); err != nil {
+		return err
+	}
+	cs := getClientSet(ctx)
+
+	patch, err := json.Marshal(map[string]map[string]string{"data": {key: value}})
+	if err != nil {
+		return err
+	}
+	_, err = cs.CoreV1().ConfigMaps(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// PatchConfigMapBinaryKey Set a single binaryData key of a config map, base64 encoded for the API
+func PatchConfigMapBinaryKey(ctx context.Context, namespace string, name string, key string, value []byte) error {
+	if err := writable(ctx); err != nil {
+		return err
+	}
+	cs := getClientSet(ctx)
+
+	patch, err := json.Marshal(map[string]map[string][]byte{"binaryData": {key: value}})
+	if err != nil {
+		return err
+	}
+	_, err = cs.CoreV1().ConfigMaps(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// PatchSecretKey Set a single key of a secret, the value is base64 encoded for the API
+func PatchSecretKey(ctx context.Context, namespace string, name string, key string, value []byte) error {
+	if err := writable(ctx); err != nil {
+		return err
+	}
+	cs := getClientSet(ctx)
+
+	// []byte values are marshalled as base64
+	patch, err := json.Marshal(map[string]map[string][]byte{"data": {key: value}})
+	if err != nil {
+		return err
+	}
+	_, err = cs.CoreV1().Secrets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// CertificateInfo Summary of an x509 certificate
+type CertificateInfo struct {
+	Subject  string
+	Issuer   string
+	SANs     []string
+	NotAfter time.Time
+}
+
+// Expired Tell whether the certificate is no longer valid
+func (c CertificateInfo) Expired() bool {
+	return time.Now().After(c.NotAfter)
+}
+
+func (c CertificateInfo) String() string {
+	expiry := fmt.Sprintf("expires %s (in %s)", c.NotAfter.Format(time.RFC3339), FormatDuration(time.Until(c.NotAfter)))
+	if c.Expired() {
+		expiry = fmt.Sprintf("EXPIRED %s", c.NotAfter.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("subject: %s\nissuer: %s\nSANs: %s\n%s", c.Subject, c.Issuer, strings.Join(c.SANs, ", "), expiry)
+}
+
+// ParseCertificates Parse the PEM certificates of a tls.crt value
+func ParseCertificates(data []byte) ([]CertificateInfo, error) {
+	var certs []CertificateInfo
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		sans := append([]string{}, c.DNSNames...)
+		for _, ip := range c.IPAddresses {
+			sans = append(sans, ip.String())
+		}
+		certs = append(certs, CertificateInfo{
+			Subject:  c.Subject.String(),
+			Issuer:   c.Issuer.String(),
+			SANs:     sans,
+			NotAfter: c.NotAfter,
+		})
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM certificate found")
+	}
+	return certs, nil
+}
@@ -0,0 +1,218 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Finding scores, a higher score is more likely the reason a pod fails
+const (
+	scoreOOMKilled      = 100
+	scoreUnschedulable  = 95
+	scoreConfigError    = 95
+	scoreImagePull      = 95
+	scoreCrashLoop      = 90
+	scoreExitCode       = 80
+	scoreProbeFailure   = 70
+	scoreShortRun       = 60
+	scoreWarningEvent   = 50
+	scoreNotReady       = 40
+	scoreProbeSettings  = 30
+	shortRunThreshold   = 10 * time.Second
+	previousLogTailSize = 20
+)
+
+// Finding is one clue of why a pod is failing
+type Finding struct {
+	Score     int
+	Container string
+	Title     string
+	Detail    string
+}
+
+// exitCodeMeanings explains the usual container exit codes
+var exitCodeMeanings = map[int32]string{
+	1:   "application error",
+	2:   "misuse of shell builtins",
+	126: "command cannot execute",
+	127: "command not found",
+	128: "invalid exit argument",
+	134: "aborted (SIGABRT)",
+	137: "killed (SIGKILL), often the OOM killer or a failed liveness probe",
+	139: "segmentation fault (SIGSEGV)",
+	143: "terminated (SIGTERM)",
+}
+
+// Diagnose Rank the clues of why a pod is failing from its container statuses, probes
+// and events, the most likely reason first
+func Diagnose(p v1.Pod, evs []v1.Event, now time.Time) []Finding {
+	var fs []Finding
+	// A pod no node can take has no container to look at, the scheduler says why
+	for _, c := range p.Status.Conditions {
+		if c.Type == v1.PodScheduled && c.Status == v1.ConditionFalse && c.Reason == v1.PodReasonUnschedulable {
+			fs = append(fs, Finding{Score: scoreUnschedulable, Title: "Unschedulable", Detail: c.Message})
+		}
+	}
+	containers := map[string]v1.Container{}
+	for _, c := range append(append([]v1.Container(nil), p.Spec.InitContainers...), p.Spec.Containers...) {
+		containers[c.Name] = c
+	}
+	statuses := append(append([]v1.ContainerStatus(nil), p.Status.InitContainerStatuses...), p.Status.ContainerStatuses...)
+	for _, s := range statuses {
+		fs = append(fs, diagnoseContainer(s, containers[s.Name], now)...)
+	}
+	fs = append(fs, diagnoseEvents(evs, containers)...)
+
+	sort.SliceStable(fs, func(i, j int) bool { return fs[i].Score > fs[j].Score })
+	return fs
+}
+
+func diagnoseContainer(s v1.ContainerStatus, c v1.Container, now time.Time) []Finding {
+	var fs []Finding
+	add := func(score int, title string, detail string) {
+		fs = append(fs, Finding{Score: score, Container: s.Name, Title: title, Detail: detail})
+	}
+
+	if w := s.State.Waiting; w != nil {
+		switch w.Reason {
+		case "CrashLoopBackOff":
+			detail := fmt.Sprintf("%d restarts", s.RestartCount)
+			if t := s.LastTerminationState.Terminated; t != nil && !t.FinishedAt.IsZero() {
+				detail = fmt.Sprintf("%s, last exit %s ago", detail, FormatDuration(now.Sub(t.FinishedAt.Time)))
+			}
+			add(scoreCrashLoop, "CrashLoopBackOff", detail)
+		case "ImagePullBackOff", "ErrImagePull", "InvalidImageName":
+			add(scoreImagePull, w.Reason+" for "+c.Image, w.Message)
+		case "CreateContainerConfigError", "CreateContainerError", "RunContainerError":
+			add(scoreConfigError, w.Reason, w.Message)
+		default:
+		}
+	}
+
+	t := s.LastTerminationState.Terminated
+	if t == nil {
+		t = s.State.Terminated
+	}
+	if t != nil {
+		switch {
+		case t.Reason == "OOMKilled":
+			limit := "no memory limit"
+			if q, ok := c.Resources.Limits[v1.ResourceMemory]; ok {
+				limit = "memory limit " + q.String()
+			}
+			add(scoreOOMKilled, "OOMKilled", "killed for using too much memory, "+limit)
+		case t.ExitCode != 0:
+			detail := exitCodeMeanings[t.ExitCode]
+			if t.Message != "" {
+				detail = strings.TrimSpace(detail + " " + t.Message)
+			}
+			add(scoreExitCode, fmt.Sprintf("Exited with code %d", t.ExitCode), detail)
+		default:
+		}
+		if t.ExitCode != 0 && !t.StartedAt.IsZero() && !t.FinishedAt.IsZero() {
+			if ran := t.FinishedAt.Sub(t.StartedAt.Time); ran < shortRunThreshold {
+				add(scoreShortRun, "Exits right after starting", fmt.Sprintf("ran for %s, check the command, args and config it reads at startup", ran))
+			}
+		}
+	}
+
+	if s.State.Running != nil && !s.Ready {
+		add(scoreNotReady, "Running but not ready", describeProbe("readiness", c.ReadinessProbe))
+	}
+	if s.RestartCount > 0 && c.LivenessProbe != nil {
+		add(scoreProbeSettings, "Liveness probe", describeProbe("liveness", c.LivenessProbe))
+	}
+	return fs
+}
+
+// diagnoseEvents Turn the warning events into findings, probe failures point at the probe settings
+func diagnoseEvents(evs []v1.Event, containers map[string]v1.Container) []Finding {
+	var fs []Finding
+	for _, e := range evs {
+		if e.Type != v1.EventTypeWarning {
+			continue
+		}
+		container := eventContainer(e)
+		f := Finding{
+			Score:     scoreWarningEvent,
+			Container: container,
+			Title:     fmt.Sprintf("%s (x%d)", e.Reason, EventCount(e)),
+			Detail:    e.Message,
+		}
+		if e.Reason == "Unhealthy" {
+			f.Score = scoreProbeFailure
+			c := containers[container]
+			switch {
+			case strings.HasPrefix(e.Message, "Liveness"):
+				f.Detail = e.Message + "; " + describeProbe("liveness", c.LivenessProbe)
+			case strings.HasPrefix(e.Message, "Readiness"):
+				f.Detail = e.Message + "; " + describeProbe("readiness", c.ReadinessProbe)
+			case strings.HasPrefix(e.Message, "Startup"):
+				f.Detail = e.Message + "; " + describeProbe("startup", c.StartupProbe)
+			default:
+			}
+		}
+		fs = append(fs, f)
+	}
+	return fs
+}
+
+// eventContainer Get the container an event is about, from a field path like spec.containers{api}
+func eventContainer(e v1.Event) string {
+	fp := e.InvolvedObject.FieldPath
+	start, end := strings.Index(fp, "{"), strings.LastIndex(fp, "}")
+	if start < 0 || end <= start {
+		return ""
+	}
+	return fp[start+1 : end]
+}
+
+// describeProbe Summarize a probe the way kubectl describe does
+func describeProbe(kind string, pr *v1.Probe) string {
+	if pr == nil {
+		return "no " + kind + " probe"
+	}
+	var action string
+	switch {
+	case pr.HTTPGet != nil:
+		action = fmt.Sprintf("http-get %s:%s", pr.HTTPGet.Path, pr.HTTPGet.Port.String())
+	case pr.TCPSocket != nil:
+		action = "tcp-socket :" + pr.TCPSocket.Port.String()
+	case pr.Exec != nil:
+		action = "exec " + strings.Join(pr.Exec.Command, " ")
+	case pr.GRPC != nil:
+		action = fmt.Sprintf("grpc :%d", pr.GRPC.Port)
+	default:
+		action = "unknown"
+	}
+	return fmt.Sprintf("%s %s delay=%ds timeout=%ds period=%ds #failure=%d",
+		kind, action, pr.InitialDelaySeconds, max(pr.TimeoutSeconds, 1), max(pr.PeriodSeconds, 10), max(pr.FailureThreshold, 3))
+}
+
+// GetPreviousLogs Get the tail of the logs of the previous run of a container
+func GetPreviousLogs(ctx context.Context, namespace string, p string, container string) (string, error) {
+	if offline != nil {
+		return offline.previousLogs(namespace, p, container, previousLogTailSize)
+	}
+	tl := int64(previousLogTailSize)
+	cs := getClientSet(ctx)
+
+	opts := &v1.PodLogOptions{
+		Container: container,
+		Previous:  true,
+		TailLines: &tl,
+	}
+	readCloser, err := cs.CoreV1().Pods(namespace).GetLogs(p, opts).Stream(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer readCloser.Close()
+	b, err := io.ReadAll(readCloser)
+	return strings.TrimRight(string(b), "\n"), err
+}
@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestDiagnose(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) metav1.Time { return metav1.NewTime(now.Add(-d)) }
+	app := v1.Container{Name: "app", Image: "shop/web:1.2"}
+	withLimit := app
+	withLimit.Resources.Limits = v1.ResourceList{v1.ResourceMemory: resource.MustParse("256Mi")}
+	withProbe := app
+	withProbe.ReadinessProbe = &v1.Probe{ProbeHandler: v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8080)}}}
+	pod := func(c v1.Container, s v1.ContainerStatus) v1.Pod {
+		s.Name = c.Name
+		return v1.Pod{
+			Spec:   v1.PodSpec{Containers: []v1.Container{c}},
+			Status: v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{s}},
+		}
+	}
+	crashLoop := v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}
+
+	// want lists the findings best first, Detail is a fragment the detail must hold
+	tests := []struct {
+		name   string
+		pod    v1.Pod
+		events []v1.Event
+		want   []Finding
+	}{
+		{
+			name: "OOMKilled",
+			pod: pod(withLimit, v1.ContainerStatus{
+				State:        crashLoop,
+				RestartCount: 3,
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+					Reason: "OOMKilled", ExitCode: 137, StartedAt: ago(time.Minute), FinishedAt: ago(30 * time.Second),
+				}},
+			}),
+			want: []Finding{
+				{Score: scoreOOMKilled, Container: "app", Title: "OOMKilled", Detail: "memory limit 256Mi"},
+				{Score: scoreCrashLoop, Container: "app", Title: "CrashLoopBackOff", Detail: "3 restarts, last exit 30s ago"},
+			},
+		},
+		{
+			name: "CrashLoopBackOff",
+			pod: pod(app, v1.ContainerStatus{
+				State:        crashLoop,
+				RestartCount: 7,
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+					ExitCode: 127, StartedAt: ago(5*time.Minute + 2*time.Second), FinishedAt: ago(5 * time.Minute),
+				}},
+			}),
+			want: []Finding{
+				{Score: scoreCrashLoop, Container: "app", Title: "CrashLoopBackOff", Detail: "7 restarts, last exit 5m ago"},
+				{Score: scoreExitCode, Container: "app", Title: "Exited with code 127", Detail: "command not found"},
+				{Score: scoreShortRun, Container: "app", Title: "Exits right after starting", Detail: "ran for 2s"},
+			},
+		},
+		{
+			name: "ImagePullBackOff",
+			pod: pod(app, v1.ContainerStatus{
+				State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: `Back-off pulling image "shop/web:1.2"`}},
+			}),
+			want: []Finding{
+				{Score: scoreImagePull, Container: "app", Title: "ImagePullBackOff for shop/web:1.2", Detail: "Back-off pulling image"},
+			},
+		},
+		{
+			name: "Unschedulable",
+			pod: v1.Pod{
+				Spec: v1.PodSpec{Containers: []v1.Container{app}},
+				Status: v1.PodStatus{Phase: v1.PodPending, Conditions: []v1.PodCondition{{
+					Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: v1.PodReasonUnschedulable,
+					Message: "0/3 nodes are available: 3 Insufficient cpu.",
+				}}},
+			},
+			events: []v1.Event{
+				{Type: v1.EventTypeWarning, Reason: "FailedScheduling", Message: "0/3 nodes are available: 3 Insufficient cpu.", Count: 4},
+			},
+			want: []Finding{
+				{Score: scoreUnschedulable, Title: "Unschedulable", Detail: "3 Insufficient cpu"},
+				{Score: scoreWarningEvent, Title: "FailedScheduling (x4)", Detail: "3 Insufficient cpu"},
+			},
+		},
+		{
+			name: "Unhealthy probe",
+			pod: pod(withProbe, v1.ContainerStatus{
+				State: v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: ago(time.Hour)}},
+			}),
+			events: []v1.Event{
+				{Type: v1.EventTypeNormal, Reason: "Pulled", Message: "Container image already present"},
+				{
+					Type: v1.EventTypeWarning, Reason: "Unhealthy", Count: 12,
+					Message:        "Readiness probe failed: HTTP probe failed with statuscode: 503",
+					InvolvedObject: v1.ObjectReference{FieldPath: "spec.containers{app}"},
+				},
+			},
+			want: []Finding{
+				{Score: scoreProbeFailure, Container: "app", Title: "Unhealthy (x12)", Detail: "readiness http-get /healthz:8080"},
+				{Score: scoreNotReady, Container: "app", Title: "Running but not ready", Detail: "readiness http-get /healthz:8080"},
+			},
+		},
+		{
+			name: "healthy",
+			pod:  pod(app, v1.ContainerStatus{State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}, Ready: true}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diagnose(tt.pod, tt.events, now)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d findings, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, w := range tt.want {
+				g := got[i]
+				if g.Score != w.Score || g.Container != w.Container || g.Title != w.Title {
+					t.Errorf("finding %d = {%d %q %q}, want {%d %q %q}", i, g.Score, g.Container, g.Title, w.Score, w.Container, w.Title)
+				}
+				if !strings.Contains(g.Detail, w.Detail) {
+					t.Errorf("finding %d detail = %q, want it to hold %q", i, g.Detail, w.Detail)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"context"
+	"k8s.io/apimachinery/pkg/fields"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// GetEvents Get events (use namespace, empty for all namespaces)
+func GetEvents(ctx context.Context, namespace string) ([]v1.Event, error) {
+	cs := getClientSet(ctx)
+
+	evs, err := cs.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return evs.Items, nil
+}
+
+// WatchEvents Watch events (use namespace, empty for all namespaces), resuming when the server ends the watch
+func WatchEvents(ctx context.Context, namespace string) (watch.Interface, error) {
+	evs := getClientSet(ctx).CoreV1().Events(namespace)
+	return retryWatch(ctx, func(ctx context.Context, o metav1.ListOptions) (metav1.ListInterface, error) {
+		return evs.List(ctx, o)
+	}, evs.Watch, metav1.ListOptions{})
+}
+
+// EventTimestamp Get the last time an event was seen
+func EventTimestamp(e v1.Event) time.Time {
+	switch {
+	case e.Series != nil && !e.Series.LastObservedTime.IsZero():
+		return e.Series.LastObservedTime.Time
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+// EventCount Get how many times an event was seen
+func EventCount(e v1.Event) int32 {
+	if e.Series != nil && e.Series.Count > 0 {
+		return e.Series.Count
+	}
+	if e.Count > 0 {
+		return e.Count
+	}
+	return 1
+}
+
+// GetObjectEvents Get the events about an object
+func GetObjectEvents(ctx context.Context, namespace string, kind string, name string) ([]v1.Event, error) {
+	cs := getClientSet(ctx)
+
+	sel := fields.Set{"involvedObject.kind": kind, "involvedObject.name": name}.AsSelector().String()
+	evs, err := cs.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: sel})
+	if err != nil {
+		return nil, err
+	}
+	return evs.Items, nil
+}
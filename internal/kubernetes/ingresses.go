@@ -0,0 +1,252 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ingressClassAnnotation is the class of ingresses older than spec.ingressClassName
+const ingressClassAnnotation = "kubernetes.io/ingress.class"
+
+// IngressRoute A rule of an ingress resolved down to its pods
+type IngressRoute struct {
+	Host     string
+	Path     string
+	Service  string
+	Port     string
+	Ready    int
+	NotReady int
+	// Pods are the pods behind the endpoints, Addresses the endpoints no pod backs
+	Pods      []string
+	Addresses []string
+	Problems  []string
+}
+
+// GetIngresses Get ingresses (use namespace)
+func GetIngresses(ctx context.Context, namespace string) ([]networkingv1.Ingress, error) {
+	cs := getClientSet(ctx)
+
+	ings, err := cs.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return ings.Items, nil
+}
+
+// ResolveIngress Resolve every rule of an ingress to its service, endpoints and pods,
+// and report the TLS problems of the ingress
+func ResolveIngress(ctx context.Context, ing networkingv1.Ingress) ([]IngressRoute, []string, error) {
+	svcs, err := GetServices(ctx, ing.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	byName := map[string]v1.Service{}
+	for _, s := range svcs {
+		byName[s.Name] = s
+	}
+	slices, err := GetEndpointSlices(ctx, ing.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var routes []IngressRoute
+	if b := ing.Spec.DefaultBackend; b != nil {
+		routes = append(routes, resolveBackend("*", "(default)", *b, byName, slices))
+	}
+	for _, r := range ing.Spec.Rules {
+		host := r.Host
+		if host == "" {
+			host = "*"
+		}
+		if r.HTTP == nil {
+			continue
+		}
+		for _, p := range r.HTTP.Paths {
+			routes = append(routes, resolveBackend(host, p.Path, p.Backend, byName, slices))
+		}
+	}
+
+	tls, err := tlsProblems(ctx, ing)
+	if err != nil {
+		return nil, nil, err
+	}
+	return routes, tls, nil
+}
+
+func resolveBackend(host string, path string, b networkingv1.IngressBackend, svcs map[string]v1.Service, slices map[string][]discoveryv1.EndpointSlice) IngressRoute {
+	r := IngressRoute{Host: host, Path: path}
+	if b.Resource != nil {
+		r.Service = b.Resource.Kind + "/" + b.Resource.Name
+		return r
+	}
+	if b.Service == nil {
+		r.Problems = append(r.Problems, "no backend")
+		return r
+	}
+	r.Service = b.Service.Name
+	r.Port = b.Service.Port.Name
+	if b.Service.Port.Number != 0 {
+		r.Port = fmt.Sprint(b.Service.Port.Number)
+	}
+
+	svc, ok := svcs[b.Service.Name]
+	if !ok {
+		r.Problems = append(r.Problems, fmt.Sprintf("service %s does not exist", b.Service.Name))
+		return r
+	}
+	port, ok := servicePort(svc, b.Service.Port)
+	if !ok {
+		r.Problems = append(r.Problems, fmt.Sprintf("service %s has no port %s", svc.Name, r.Port))
+	}
+
+	var serving []discoveryv1.EndpointSlice
+	for _, s := range slices[svc.Name] {
+		if !ok || slicePortMatches(s, port) {
+			serving = append(serving, s)
+		}
+	}
+	r.Ready, r.NotReady = EndpointCounts(serving)
+	if r.Ready == 0 && svc.Spec.Type != v1.ServiceTypeExternalName {
+		r.Problems = append(r.Problems, "no ready endpoints")
+	}
+
+	// The endpoints name their pods, services without a selector list bare addresses
+	seen := map[string]bool{}
+	for _, s := range serving {
+		for _, e := range s.Endpoints {
+			k := endpointKey(e)
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			pod := e.TargetRef != nil && e.TargetRef.Kind == "Pod"
+			name := strings.Join(e.Addresses, ",")
+			if pod {
+				name = e.TargetRef.Name
+			}
+			if e.Conditions.Ready != nil && !*e.Conditions.Ready {
+				name += " (not ready)"
+			}
+			if pod {
+				r.Pods = append(r.Pods, name)
+			} else {
+				r.Addresses = append(r.Addresses, name)
+			}
+		}
+	}
+	return r
+}
+
+// servicePort Find the service port an ingress backend points to
+func servicePort(svc v1.Service, p networkingv1.ServiceBackendPort) (v1.ServicePort, bool) {
+	for _, sp := range svc.Spec.Ports {
+		if (p.Number != 0 && sp.Port == p.Number) || (p.Name != "" && sp.Name == p.Name) {
+			return sp, true
+		}
+	}
+	return v1.ServicePort{}, false
+}
+
+// slicePortMatches Tell whether an endpoint slice serves a service port, slices are named after the port
+func slicePortMatches(s discoveryv1.EndpointSlice, sp v1.ServicePort) bool {
+	if len(s.Ports) == 0 {
+		return true
+	}
+	for _, p := range s.Ports {
+		if p.Name != nil && *p.Name == sp.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// tlsProblems Check the TLS secrets of an ingress exist and hold valid certificates
+func tlsProblems(ctx context.Context, ing networkingv1.Ingress) ([]string, error) {
+	cs := getClientSet(ctx)
+
+	var problems []string
+	for _, t := range ing.Spec.TLS {
+		if t.SecretName == "" {
+			continue
+		}
+		s, err := cs.CoreV1().Secrets(ing.Namespace).Get(ctx, t.SecretName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			problems = append(problems, fmt.Sprintf("TLS secret %s does not exist", t.SecretName))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		certs, err := ParseCertificates(s.Data[v1.TLSCertKey])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("TLS secret %s: %v", t.SecretName, err))
+			continue
+		}
+		// An expired intermediate breaks the chain as much as an expired leaf
+		for _, c := range certs {
+			if c.Expired() {
+				problems = append(problems, fmt.Sprintf("TLS secret %s: %s expired on %s", t.SecretName, c.Subject, c.NotAfter.Format("2006-01-02")))
+			}
+		}
+	}
+	return problems, nil
+}
+
+// ColumnHelperIngressClass Column helper: Ingress class
+func ColumnHelperIngressClass(ing networkingv1.Ingress) string {
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName
+	}
+	if c := ing.Annotations[ingressClassAnnotation]; c != "" {
+		return c
+	}
+	return "<none>"
+}
+
+// ColumnHelperHosts Column helper: Ingress hosts
+func ColumnHelperHosts(ing networkingv1.Ingress) string {
+	var hs []string
+	for _, r := range ing.Spec.Rules {
+		if r.Host != "" {
+			hs = append(hs, r.Host)
+		}
+	}
+	if len(hs) == 0 {
+		return "*"
+	}
+	return strings.Join(hs, ",")
+}
+
+// ColumnHelperTLSSecrets Column helper: Ingress TLS secrets
+func ColumnHelperTLSSecrets(ing networkingv1.Ingress) string {
+	var ss []string
+	for _, t := range ing.Spec.TLS {
+		if t.SecretName != "" {
+			ss = append(ss, t.SecretName)
+		}
+	}
+	if len(ss) == 0 {
+		return "<none>"
+	}
+	return strings.Join(ss, ",")
+}
+
+// ColumnHelperIngressAddress Column helper: Ingress load balancer addresses
+func ColumnHelperIngressAddress(ing networkingv1.Ingress) string {
+	var as []string
+	for _, i := range ing.Status.LoadBalancer.Ingress {
+		if i.IP != "" {
+			as = append(as, i.IP)
+		} else if i.Hostname != "" {
+			as = append(as, i.Hostname)
+		}
+	}
+	return strings.Join(as, ",")
+}
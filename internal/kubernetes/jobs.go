@@ -0,0 +1,234 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"k8s.io/apimachinery/pkg/types"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// instantiateAnnotation marks jobs created by hand from a cron job, as kubectl does
+	instantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+	// maxNameLength leaves room for the generated suffix of a job name
+	maxNameLength = 52
+)
+
+// jobControllerLabels are set by the job controller and must not be copied to a new job
+var jobControllerLabels = []string{
+	"controller-uid",
+	"job-name",
+	batchv1.ControllerUidLabel,
+	batchv1.JobNameLabel,
+}
+
+// jobControllerAnnotations are set by the job and cron job controllers, a rerun keeping them would
+// pass for a scheduled run
+var jobControllerAnnotations = []string{
+	batchv1.CronJobScheduledTimestampAnnotation,
+	"batch.kubernetes.io/job-tracking",
+}
+
+// GetJobs Get jobs (use namespace)
+func GetJobs(ctx context.Context, namespace string) ([]batchv1.Job, error) {
+	cs := getClientSet(ctx)
+
+	jobs, err := cs.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return jobs.Items, nil
+}
+
+// GetCronJobs Get cron jobs (use namespace)
+func GetCronJobs(ctx context.Context, namespace string) ([]batchv1.CronJob, error) {
+	cs := getClientSet(ctx)
+
+	cjs, err := cs.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return cjs.Items, nil
+}
+
+// TriggerCronJob Create a job from the cron job template now, owned by the cron job
+func TriggerCronJob(ctx context.Context, cj batchv1.CronJob) (*batchv1.Job, error) {
+	if err := writable(ctx); err != nil {
+		return nil, err
+	}
+	cs := getClientSet(ctx)
+
+	annotations := map[string]string{instantiateAnnotation: "manual"}
+	for k, v := range cj.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: truncateName(cj.Name) + "-manual-",
+			Namespace:    cj.Namespace,
+			Labels:       cj.Spec.JobTemplate.Labels,
+			Annotations:  annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(&cj, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: *cj.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+	return cs.BatchV1().Jobs(cj.Namespace).Create(ctx, job, metav1.CreateOptions{})
+}
+
+// SuspendCronJob Suspend or resume a cron job
+func SuspendCronJob(ctx context.Context, namespace string, name string, suspend bool) error {
+	if err := writable(ctx); err != nil {
+		return err
+	}
+	cs := getClientSet(ctx)
+
+	patch := fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend)
+	_, err := cs.BatchV1().CronJobs(namespace).Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	return err
+}
+
+// RerunJob Create a copy of a job, without the selector, labels and annotations the controllers
+// generated. The copy stays owned by its cron job but isn't controlled by it, so it counts neither
+// against the concurrency policy nor the history limits
+func RerunJob(ctx context.Context, job batchv1.Job) (*batchv1.Job, error) {
+	if err := writable(ctx); err != nil {
+		return nil, err
+	}
+	cs := getClientSet(ctx)
+
+	spec := job.Spec.DeepCopy()
+	spec.Selector = nil
+	spec.ManualSelector = nil
+	spec.Template.Labels = cleanLabels(spec.Template.Labels)
+
+	rerun := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName:    truncateName(job.Name) + "-rerun-",
+			Namespace:       job.Namespace,
+			Labels:          cleanLabels(job.Labels),
+			Annotations:     cleanAnnotations(job.Annotations),
+			OwnerReferences: uncontrolled(job.OwnerReferences),
+		},
+		Spec: *spec,
+	}
+	return cs.BatchV1().Jobs(job.Namespace).Create(ctx, rerun, metav1.CreateOptions{})
+}
+
+// JobPodsSelector Label selector matching the pods of a job
+func JobPodsSelector(job batchv1.Job) string {
+	if job.Spec.Selector == nil {
+		return batchv1.JobNameLabel + "=" + job.Name
+	}
+	s, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return batchv1.JobNameLabel + "=" + job.Name
+	}
+	return s.String()
+}
+
+// JobFailed Tell whether a job has failed
+func JobFailed(job batchv1.Job) bool {
+	return jobCondition(job, batchv1.JobFailed)
+}
+
+func jobCondition(job batchv1.Job, t batchv1.JobConditionType) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == t && c.Status == "True" {
+			return true
+		}
+	}
+	return false
+}
+
+// ColumnHelperJobStatus Column helper: Job status
+func ColumnHelperJobStatus(job batchv1.Job) string {
+	switch {
+	case jobCondition(job, batchv1.JobComplete):
+		return "Complete"
+	case JobFailed(job):
+		return "Failed"
+	case jobCondition(job, batchv1.JobSuspended):
+		return "Suspended"
+	case job.Status.Active > 0:
+		return "Running"
+	default:
+		return "Pending"
+	}
+}
+
+// ColumnHelperCompletions Column helper: Job completions
+func ColumnHelperCompletions(job batchv1.Job) string {
+	if job.Spec.Completions == nil {
+		if job.Spec.Parallelism != nil && *job.Spec.Parallelism > 1 {
+			return fmt.Sprintf("%d/1 of %d", job.Status.Succeeded, *job.Spec.Parallelism)
+		}
+		return fmt.Sprintf("%d/1", job.Status.Succeeded)
+	}
+	return fmt.Sprintf("%d/%d", job.Status.Succeeded, *job.Spec.Completions)
+}
+
+// ColumnHelperDuration Column helper: Job duration
+func ColumnHelperDuration(job batchv1.Job) string {
+	if job.Status.StartTime == nil {
+		return ""
+	}
+	end := time.Now()
+	if job.Status.CompletionTime != nil {
+		end = job.Status.CompletionTime.Time
+	}
+	return FormatDuration(end.Sub(job.Status.StartTime.Time))
+}
+
+// ColumnHelperLastSchedule Column helper: Cron job last schedule
+func ColumnHelperLastSchedule(cj batchv1.CronJob) string {
+	if cj.Status.LastScheduleTime == nil {
+		return "<none>"
+	}
+	return ColumnHelperAge(*cj.Status.LastScheduleTime)
+}
+
+func truncateName(name string) string {
+	if len(name) > maxNameLength {
+		return name[:maxNameLength]
+	}
+	return name
+}
+
+// cleanLabels Copy labels without the ones the job controller sets
+func cleanLabels(l map[string]string) map[string]string {
+	c := map[string]string{}
+	for k, v := range l {
+		c[k] = v
+	}
+	for _, k := range jobControllerLabels {
+		delete(c, k)
+	}
+	return c
+}
+
+// cleanAnnotations Copy annotations without the ones the controllers set
+func cleanAnnotations(a map[string]string) map[string]string {
+	c := map[string]string{}
+	for k, v := range a {
+		c[k] = v
+	}
+	for _, k := range jobControllerAnnotations {
+		delete(c, k)
+	}
+	return c
+}
+
+// uncontrolled Copy owner references with none of them the controller
+func uncontrolled(refs []metav1.OwnerReference) []metav1.OwnerReference {
+	c := make([]metav1.OwnerReference, 0, len(refs))
+	for _, r := range refs {
+		r.Controller = nil
+		c = append(c, r)
+	}
+	return c
+}
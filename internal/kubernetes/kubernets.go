@@ -13,7 +13,6 @@ import (
 	"strconv"
 	"time"
 
-	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/tools/clientcmd"
 
@@ -23,9 +22,10 @@ import (
 
 type config struct {
 	kubeConfig *string
+	snapshot   *string
 }
 
-// Get configuration
+// Get configuration, the flags are parsed by main
 func getConfig() config {
 	c := config{}
 
@@ -34,31 +34,22 @@ func getConfig() config {
 	} else {
 		c.kubeConfig = flag.String("kubeconfig", "", "absolute path to the kubeconfig file")
 	}
-	flag.Parse()
+	c.snapshot = flag.String("snapshot", "", "browse a directory written by the snapshot save command instead of a cluster")
 
 	return c
 }
 
-var kubeConfig = getConfig().kubeConfig
+var flags = getConfig()
 
-// Get Kubernetes client set
-func getClientSet() *kubernetes.Clientset {
-	// Use the current context in kubeconfig
-	cc, err := clientcmd.BuildConfigFromFlags("", *kubeConfig)
-	if err != nil {
-		panic(err)
-	}
+var kubeConfig = flags.kubeConfig
 
-	// Create the client set
-	cs, err := kubernetes.NewForConfig(cc)
-	if err != nil {
-		panic(err)
-	}
-
-	return cs
-}
+// snapshotDir is the dump given with --snapshot
+var snapshotDir = flags.snapshot
 
 func ListContexts() map[string]*api.Context {
+	if offline != nil {
+		return offline.contexts()
+	}
 	config, err := clientcmd.LoadFromFile(*kubeConfig)
 	if err != nil {
 		panic(err)
@@ -68,6 +59,9 @@ func ListContexts() map[string]*api.Context {
 }
 
 func GetCurrent() (string, string, string) {
+	if offline != nil {
+		return offline.info.Context, offline.info.Namespace, ""
+	}
 	config, err := clientcmd.LoadFromFile(*kubeConfig)
 	if err != nil {
 		panic(err)
@@ -79,19 +73,26 @@ func GetCurrent() (string, string, string) {
 	return name, namespace, user
 }
 
-func SetContext(clusterName string, namespace string, usr string) {
+// SetContext Make the named context the current one of the kubeconfig with its namespace and user
+func SetContext(name string, namespace string, usr string) {
+	// A snapshot has a single context and the kubeconfig is none of its business
+	if offline != nil {
+		return
+	}
 
 	config, err := clientcmd.LoadFromFile(*kubeConfig)
 	if err != nil {
 		panic(err)
 	}
-	ctx := api.NewContext()
-	ctx.Cluster = clusterName
+	ctx, ok := config.Contexts[name]
+	if !ok {
+		ctx = api.NewContext()
+		ctx.Cluster = name
+		config.Contexts[name] = ctx
+	}
 	ctx.Namespace = namespace
 	ctx.AuthInfo = usr
-
-	config.Contexts[clusterName] = ctx
-	config.CurrentContext = clusterName
+	config.CurrentContext = name
 
 	err = clientcmd.WriteToFile(*config, *kubeConfig)
 	if err != nil {
@@ -101,7 +102,7 @@ func SetContext(clusterName string, namespace string, usr string) {
 
 // GetPods Get pods (use namespace)
 func GetPods(ctx context.Context, namespace string) ([]v1.Pod, error) {
-	cs := getClientSet()
+	cs := getClientSet(ctx)
 
 	pds, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -110,21 +111,28 @@ func GetPods(ctx context.Context, namespace string) ([]v1.Pod, error) {
 	return pds.Items, nil
 }
 
-func WatchPods(ctx context.Context, namespace string) (watch.Interface, error) {
-	cs := getClientSet()
+// GetPodsWithSelector Get pods matching a label selector (use namespace)
+func GetPodsWithSelector(ctx context.Context, namespace string, selector string) ([]v1.Pod, error) {
+	cs := getClientSet(ctx)
 
-	w, err := cs.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{})
+	pds, err := cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
 	if err != nil {
 		return nil, err
 	}
+	return pds.Items, nil
+}
 
-	return w, nil
-
+// WatchPods Watch pods matching the list options (use namespace), resuming when the server ends the watch
+func WatchPods(ctx context.Context, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+	pods := getClientSet(ctx).CoreV1().Pods(namespace)
+	return retryWatch(ctx, func(ctx context.Context, o metav1.ListOptions) (metav1.ListInterface, error) {
+		return pods.List(ctx, o)
+	}, pods.Watch, opts)
 }
 
 // GetNamespaces Get namespaces
 func GetNamespaces(ctx context.Context) ([]v1.Namespace, error) {
-	cs := getClientSet()
+	cs := getClientSet(ctx)
 
 	ns, err := cs.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -133,12 +141,22 @@ func GetNamespaces(ctx context.Context) ([]v1.Namespace, error) {
 	return ns.Items, nil
 }
 
-// GetPodLogs Get pod container logs
-func GetPodLogs(ctx context.Context, namespace string, p string, logChan chan<- string) error {
-	tl := int64(50)
-	cs := getClientSet()
+// GetPod Get a pod by name
+func GetPod(ctx context.Context, namespace string, name string) (*v1.Pod, error) {
+	cs := getClientSet(ctx)
+	return cs.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// GetPodLogs Get pod container logs, an empty container is the default one
+func GetPodLogs(ctx context.Context, namespace string, p string, container string, tail int64, logChan chan<- string) error {
+	if offline != nil {
+		return snapshotLogs(ctx, namespace, p, container, tail, logChan)
+	}
+	tl := tail
+	cs := getClientSet(ctx)
 
 	opts := &v1.PodLogOptions{
+		Container:                    container,
 		InsecureSkipTLSVerifyBackend: true,
 		TailLines:                    &tl,
 		Follow:                       true, // Follow the log stream of the pod
@@ -148,8 +166,6 @@ func GetPodLogs(ctx context.Context, namespace string, p string, logChan chan<-
 
 	readCloser, err := req.Stream(ctx)
 	if err != nil {
-		errMsg := fmt.Errorf("errMsg in opening stream: %v", err)
-		fmt.Println(errMsg)
 		return err
 	}
 
@@ -175,19 +191,106 @@ func GetPodLogs(ctx context.Context, namespace string, p string, logChan chan<-
 	return readCloser.Close()
 }
 
+// LogOptions Choose the logs WritePodLogs copies
+type LogOptions struct {
+	// Container is the container of the pod, empty for the default one
+	Container string
+	// Since keeps the logs of the last duration, zero keeps them all
+	Since time.Duration
+	// Tail keeps the last lines, negative keeps them all
+	Tail   int64
+	Follow bool
+}
+
+// WritePodLogs Copy the logs of a pod to a writer, until they end or, when following, until ctx is done
+func WritePodLogs(ctx context.Context, namespace string, p string, o LogOptions, w io.Writer) error {
+	if offline != nil {
+		// The saved lines have no time, Since keeps them all
+		logChan := make(chan string)
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- snapshotLogs(ctx, namespace, p, o.Container, o.Tail, logChan)
+			close(logChan)
+		}()
+		for line := range logChan {
+			if _, err := io.WriteString(w, line); err != nil {
+				return err
+			}
+		}
+		return <-errChan
+	}
+	cs := getClientSet(ctx)
+
+	opts := &v1.PodLogOptions{Container: o.Container, Follow: o.Follow}
+	if o.Since > 0 {
+		s := int64(o.Since.Seconds())
+		opts.SinceSeconds = &s
+	}
+	if o.Tail >= 0 {
+		t := o.Tail
+		opts.TailLines = &t
+	}
+	readCloser, err := cs.CoreV1().Pods(namespace).GetLogs(p, opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer readCloser.Close()
+	_, err = io.Copy(w, readCloser)
+	return err
+}
+
 // ColumnHelperRestarts Column helper: Restarts
 func ColumnHelperRestarts(cs []v1.ContainerStatus) string {
+	return strconv.Itoa(RestartCount(cs))
+}
+
+// RestartCount Sum the restarts of the containers
+func RestartCount(cs []v1.ContainerStatus) int {
 	r := 0
 	for _, c := range cs {
 		r = r + int(c.RestartCount)
 	}
-	return strconv.Itoa(r)
+	return r
+}
+
+// DefaultContainer Get the container kubectl picks for a pod, the annotated one or the first
+func DefaultContainer(p v1.Pod) string {
+	if c := p.Annotations["kubectl.kubernetes.io/default-container"]; c != "" {
+		return c
+	}
+	if len(p.Spec.Containers) > 0 {
+		return p.Spec.Containers[0].Name
+	}
+	return ""
+}
+
+// PodStatusReason Get the status kubectl shows for a pod, the container reason wins over the phase
+func PodStatusReason(p v1.Pod) string {
+	if p.DeletionTimestamp != nil {
+		return "Terminating"
+	}
+	reason := string(p.Status.Phase)
+	if p.Status.Reason != "" {
+		reason = p.Status.Reason
+	}
+	for _, c := range p.Status.ContainerStatuses {
+		switch {
+		case c.State.Waiting != nil && c.State.Waiting.Reason != "":
+			return c.State.Waiting.Reason
+		case c.State.Terminated != nil && c.State.Terminated.Reason != "":
+			reason = c.State.Terminated.Reason
+		}
+	}
+	return reason
 }
 
 // ColumnHelperAge Column helper: Age
 func ColumnHelperAge(t metav1.Time) string {
-	d := time.Now().Sub(t.Time)
+	return FormatDuration(time.Now().Sub(t.Time))
+}
 
+// FormatDuration Format a duration the way the Age column does
+func FormatDuration(d time.Duration) string {
 	if d.Hours() > 1 {
 		if d.Hours() > 24 {
 			ds := float64(d.Hours() / 24)
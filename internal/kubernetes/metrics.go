@@ -0,0 +1,128 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"k8s.io/metrics/pkg/client/clientset/versioned"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrMetricsUnavailable is returned when metrics.k8s.io is not served, usually no metrics-server
+var ErrMetricsUnavailable = errors.New("metrics API not available")
+
+// MetricsClient Source of pod and node usage, replaced by a fake in tests
+type MetricsClient interface {
+	PodMetrics(ctx context.Context, namespace string, opts metav1.ListOptions) ([]metricsv1beta1.PodMetrics, error)
+	NodeMetrics(ctx context.Context) ([]metricsv1beta1.NodeMetrics, error)
+}
+
+// metricsServer Reads usage from metrics.k8s.io
+type metricsServer struct {
+	cs versioned.Interface
+}
+
+func (s metricsServer) PodMetrics(ctx context.Context, namespace string, opts metav1.ListOptions) ([]metricsv1beta1.PodMetrics, error) {
+	pms, err := s.cs.MetricsV1beta1().PodMetricses(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pms.Items, nil
+}
+
+func (s metricsServer) NodeMetrics(ctx context.Context) ([]metricsv1beta1.NodeMetrics, error) {
+	nms, err := s.cs.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return nms.Items, nil
+}
+
+// brokenMetrics Fails every call with the error that kept the metrics client from being built
+type brokenMetrics struct {
+	err error
+}
+
+func (b brokenMetrics) PodMetrics(context.Context, string, metav1.ListOptions) ([]metricsv1beta1.PodMetrics, error) {
+	return nil, b.err
+}
+
+func (b brokenMetrics) NodeMetrics(context.Context) ([]metricsv1beta1.NodeMetrics, error) {
+	return nil, b.err
+}
+
+// NewMetricsClient Get a metrics client for the kube context of a call, built once per context
+var NewMetricsClient = func(ctx context.Context) MetricsClient {
+	cs, err := getMetricsClientSet(ctx)
+	if err != nil {
+		return brokenMetrics{err: err}
+	}
+	return metricsServer{cs: cs}
+}
+
+// GetPodUsage Get the usage of each pod keyed by namespace/name (use namespace)
+func GetPodUsage(ctx context.Context, namespace string, opts metav1.ListOptions) (map[string]v1.ResourceList, error) {
+	pms, err := NewMetricsClient(ctx).PodMetrics(ctx, namespace, opts)
+	if err != nil {
+		return nil, metricsError(err)
+	}
+	usage := map[string]v1.ResourceList{}
+	for _, pm := range pms {
+		u := v1.ResourceList{}
+		for _, c := range pm.Containers {
+			addResources(u, c.Usage)
+		}
+		usage[pm.Namespace+"/"+pm.Name] = u
+	}
+	return usage, nil
+}
+
+// GetNodeUsage Get the usage of each node keyed by name
+func GetNodeUsage(ctx context.Context) (map[string]v1.ResourceList, error) {
+	nms, err := NewMetricsClient(ctx).NodeMetrics(ctx)
+	if err != nil {
+		return nil, metricsError(err)
+	}
+	usage := map[string]v1.ResourceList{}
+	for _, nm := range nms {
+		usage[nm.Name] = nm.Usage
+	}
+	return usage, nil
+}
+
+// metricsError Map a missing metrics API to ErrMetricsUnavailable
+func metricsError(err error) error {
+	if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+		return ErrMetricsUnavailable
+	}
+	return err
+}
+
+// PodLimits Get the resource limits of a pod, empty when a container has none
+func PodLimits(p v1.Pod) v1.ResourceList {
+	limits := v1.ResourceList{}
+	for _, n := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		for _, c := range p.Spec.Containers {
+			q, ok := c.Resources.Limits[n]
+			if !ok {
+				delete(limits, n)
+				break
+			}
+			r := limits[n]
+			r.Add(q)
+			limits[n] = r
+		}
+	}
+	return limits
+}
+
+func addResources(to v1.ResourceList, rl v1.ResourceList) {
+	for n, q := range rl {
+		r := to[n]
+		r.Add(q)
+		to[n] = r
+	}
+}
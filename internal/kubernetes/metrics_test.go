@@ -0,0 +1,104 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// fakeMetrics Serves fixed metrics, or fails with err
+type fakeMetrics struct {
+	pods  []metricsv1beta1.PodMetrics
+	nodes []metricsv1beta1.NodeMetrics
+	err   error
+}
+
+func (f fakeMetrics) PodMetrics(context.Context, string, metav1.ListOptions) ([]metricsv1beta1.PodMetrics, error) {
+	return f.pods, f.err
+}
+
+func (f fakeMetrics) NodeMetrics(context.Context) ([]metricsv1beta1.NodeMetrics, error) {
+	return f.nodes, f.err
+}
+
+func useMetrics(t *testing.T, c MetricsClient) {
+	old := NewMetricsClient
+	NewMetricsClient = func(context.Context) MetricsClient { return c }
+	t.Cleanup(func() { NewMetricsClient = old })
+}
+
+func usage(cpu string, mem string) v1.ResourceList {
+	return v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu), v1.ResourceMemory: resource.MustParse(mem)}
+}
+
+func TestGetPodUsage(t *testing.T) {
+	useMetrics(t, fakeMetrics{pods: []metricsv1beta1.PodMetrics{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"},
+		Containers: []metricsv1beta1.ContainerMetrics{
+			{Name: "app", Usage: usage("250m", "100Mi")},
+			{Name: "proxy", Usage: usage("50m", "28Mi")},
+		},
+	}}})
+
+	got, err := GetPodUsage(context.Background(), "shop", metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, ok := got["shop/web"]
+	if !ok {
+		t.Fatalf("no usage for shop/web in %v", got)
+	}
+	if cpu := u[v1.ResourceCPU]; cpu.MilliValue() != 300 {
+		t.Errorf("cpu = %s, want 300m", cpu.String())
+	}
+	if mem := u[v1.ResourceMemory]; mem.Value() != 128*1024*1024 {
+		t.Errorf("memory = %s, want 128Mi", mem.String())
+	}
+}
+
+func TestGetNodeUsage(t *testing.T) {
+	useMetrics(t, fakeMetrics{nodes: []metricsv1beta1.NodeMetrics{{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Usage:      usage("1500m", "2Gi"),
+	}}})
+
+	got, err := GetNodeUsage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cpu := got["node-1"][v1.ResourceCPU]; cpu.MilliValue() != 1500 {
+		t.Errorf("cpu = %s, want 1500m", cpu.String())
+	}
+}
+
+func TestMetricsErrors(t *testing.T) {
+	gr := schema.GroupResource{Group: "metrics.k8s.io", Resource: "pods"}
+	other := errors.New("connection refused")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"not served", apierrors.NewNotFound(gr, ""), ErrMetricsUnavailable},
+		{"metrics-server down", apierrors.NewServiceUnavailable("no endpoints"), ErrMetricsUnavailable},
+		{"other errors kept", other, other},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useMetrics(t, fakeMetrics{err: tt.err})
+			if _, err := GetPodUsage(context.Background(), "", metav1.ListOptions{}); !errors.Is(err, tt.want) {
+				t.Errorf("GetPodUsage error = %v, want %v", err, tt.want)
+			}
+			if _, err := GetNodeUsage(context.Background()); !errors.Is(err, tt.want) {
+				t.Errorf("GetNodeUsage error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
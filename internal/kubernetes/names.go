@@ -0,0 +1,90 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+)
+
+// kindResources maps the kinds names can be listed for to their API resource
+var kindResources = map[string]schema.GroupVersionResource{
+	"Pod":                   {Version: "v1", Resource: "pods"},
+	"Service":               {Version: "v1", Resource: "services"},
+	"ConfigMap":             {Version: "v1", Resource: "configmaps"},
+	"Secret":                {Version: "v1", Resource: "secrets"},
+	"PersistentVolumeClaim": {Version: "v1", Resource: "persistentvolumeclaims"},
+	"PersistentVolume":      {Version: "v1", Resource: "persistentvolumes"},
+	"Node":                  {Version: "v1", Resource: "nodes"},
+	"Namespace":             {Version: "v1", Resource: "namespaces"},
+	"Deployment":            {Group: "apps", Version: "v1", Resource: "deployments"},
+	"StatefulSet":           {Group: "apps", Version: "v1", Resource: "statefulsets"},
+	"DaemonSet":             {Group: "apps", Version: "v1", Resource: "daemonsets"},
+	"Job":                   {Group: "batch", Version: "v1", Resource: "jobs"},
+	"CronJob":               {Group: "batch", Version: "v1", Resource: "cronjobs"},
+	"Ingress":               {Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+}
+
+// clusterScoped are the kinds that ignore the namespace
+var clusterScoped = map[string]bool{"PersistentVolume": true, "Node": true, "Namespace": true}
+
+// ListNames Get the sorted names of the objects of a kind (use namespace), only their metadata is fetched
+func ListNames(ctx context.Context, kind string, namespace string) ([]string, error) {
+	gvr, ok := kindResources[kind]
+	if !ok {
+		return nil, fmt.Errorf("can't list %s", kind)
+	}
+	if offline != nil {
+		names := offline.names(kind, namespace)
+		sort.Strings(names)
+		return names, nil
+	}
+	mc, err := metadata.NewForConfig(getRestConfig(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var list *metav1.PartialObjectMetadataList
+	if clusterScoped[kind] {
+		list, err = mc.Resource(gvr).List(ctx, metav1.ListOptions{})
+	} else {
+		list, err = mc.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, o := range list.Items {
+		names = append(names, o.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ContextNames Get the sorted names the context list shows
+func ContextNames() []string {
+	var names []string
+	for n := range ListContexts() {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ContainerNames Get the names of the containers of a pod, init containers first
+func ContainerNames(ctx context.Context, namespace string, pod string) ([]string, error) {
+	p, err := GetPod(ctx, namespace, pod)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, c := range p.Spec.InitContainers {
+		names = append(names, c.Name)
+	}
+	for _, c := range p.Spec.Containers {
+		names = append(names, c.Name)
+	}
+	return names, nil
+}
@@ -0,0 +1,372 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+	evictionRetryDelay  = 5 * time.Second
+	drainTimeout        = 5 * time.Minute
+)
+
+// GetNodes Get nodes
+func GetNodes(ctx context.Context) ([]v1.Node, error) {
+	cs := getClientSet(ctx)
+
+	nds, err := cs.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return nds.Items, nil
+}
+
+// NodePodsSelector Field selector matching the pods scheduled on a node
+func NodePodsSelector(node string) string {
+	return "spec.nodeName=" + node
+}
+
+// GetNodePods Get pods scheduled on a node, in every namespace
+func GetNodePods(ctx context.Context, node string) ([]v1.Pod, error) {
+	cs := getClientSet(ctx)
+
+	pds, err := cs.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: NodePodsSelector(node)})
+	if err != nil {
+		return nil, err
+	}
+	return pds.Items, nil
+}
+
+// CordonNode Mark a node as (un)schedulable
+func CordonNode(ctx context.Context, node string, unschedulable bool) error {
+	if err := writable(ctx); err != nil {
+		return err
+	}
+	cs := getClientSet(ctx)
+
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	_, err := cs.CoreV1().Nodes().Patch(ctx, node, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	return err
+}
+
+// DrainOptions Choose which pods a drain may delete for good, like the kubectl drain flags
+type DrainOptions struct {
+	// Force evicts the pods no controller will recreate
+	Force bool
+	// DeleteEmptyDirData evicts the pods whose emptyDir volumes are lost with them
+	DeleteEmptyDirData bool
+}
+
+// DrainPlan Sort the pods of a node the way a drain treats them
+type DrainPlan struct {
+	Evict     []v1.Pod
+	Ignored   []v1.Pod
+	Unmanaged []v1.Pod
+	EmptyDir  []v1.Pod
+}
+
+// PlanDrain List the pods of a node a drain would evict, ignore or only delete when told to
+func PlanDrain(ctx context.Context, node string) (DrainPlan, error) {
+	pds, err := GetNodePods(ctx, node)
+	if err != nil {
+		return DrainPlan{}, err
+	}
+	var plan DrainPlan
+	for _, p := range pds {
+		switch {
+		case p.Status.Phase == v1.PodSucceeded || p.Status.Phase == v1.PodFailed:
+		case isDaemonSetPod(p), p.Annotations[mirrorPodAnnotation] != "":
+			plan.Ignored = append(plan.Ignored, p)
+		case metav1.GetControllerOf(&p) == nil:
+			plan.Unmanaged = append(plan.Unmanaged, p)
+		case hasEmptyDir(p):
+			plan.EmptyDir = append(plan.EmptyDir, p)
+		default:
+			plan.Evict = append(plan.Evict, p)
+		}
+	}
+	return plan, nil
+}
+
+// DrainNode Cordon a node and evict its pods, reporting progress like kubectl drain. Pods no
+// controller manages or with emptyDir data are skipped unless the options allow deleting them
+func DrainNode(ctx context.Context, node string, opts DrainOptions, progress chan<- string) error {
+	if err := writable(ctx); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, drainTimeout)
+	defer cancel()
+
+	report := func(format string, a ...interface{}) {
+		select {
+		case progress <- fmt.Sprintf(format, a...):
+		case <-ctx.Done():
+		}
+	}
+
+	if err := CordonNode(ctx, node, true); err != nil {
+		return err
+	}
+	report("node/%s cordoned", node)
+
+	plan, err := PlanDrain(ctx, node)
+	if err != nil {
+		return err
+	}
+	for _, p := range plan.Ignored {
+		if isDaemonSetPod(p) {
+			report("ignoring DaemonSet-managed pod %s/%s", p.Namespace, p.Name)
+		} else {
+			report("ignoring mirror pod %s/%s", p.Namespace, p.Name)
+		}
+	}
+	evict := plan.Evict
+	for _, p := range plan.Unmanaged {
+		switch {
+		case !opts.Force:
+			report("skipping pod %s/%s, no controller manages it", p.Namespace, p.Name)
+		case hasEmptyDir(p) && !opts.DeleteEmptyDirData:
+			report("skipping pod %s/%s, it has emptyDir data", p.Namespace, p.Name)
+		default:
+			report("deleting unmanaged pod %s/%s", p.Namespace, p.Name)
+			evict = append(evict, p)
+		}
+	}
+	for _, p := range plan.EmptyDir {
+		if !opts.DeleteEmptyDirData {
+			report("skipping pod %s/%s, it has emptyDir data", p.Namespace, p.Name)
+			continue
+		}
+		report("deleting emptyDir data of pod %s/%s", p.Namespace, p.Name)
+		evict = append(evict, p)
+	}
+
+	// Evict every pod at once like kubectl, a disruption budget only holds back its own pods
+	errs := make([]error, len(evict))
+	var wg sync.WaitGroup
+	for i, p := range evict {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report("[%d/%d] evicting pod %s/%s", i+1, len(evict), p.Namespace, p.Name)
+			if err := evictPod(ctx, p, report); err != nil {
+				errs[i] = fmt.Errorf("evicting pod %s/%s: %w", p.Namespace, p.Name, err)
+				return
+			}
+			if err := waitPodDeleted(ctx, p); err != nil {
+				errs[i] = fmt.Errorf("waiting for pod %s/%s: %w", p.Namespace, p.Name, err)
+				return
+			}
+			report("pod/%s evicted", p.Name)
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	report("node/%s drained", node)
+	return nil
+}
+
+// evictPod Evict a pod, retrying while a disruption budget blocks it
+func evictPod(ctx context.Context, p v1.Pod, report func(string, ...interface{})) error {
+	cs := getClientSet(ctx)
+
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Name: p.Name, Namespace: p.Namespace},
+	}
+	for {
+		err := cs.PolicyV1().Evictions(p.Namespace).Evict(ctx, eviction)
+		switch {
+		case err == nil || apierrors.IsNotFound(err):
+			return nil
+		case apierrors.IsTooManyRequests(err):
+			report("pod %s/%s blocked by a disruption budget, retrying in %s", p.Namespace, p.Name, evictionRetryDelay)
+		default:
+			return err
+		}
+
+		select {
+		case <-time.After(evictionRetryDelay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// waitPodDeleted Wait until an evicted pod is gone
+func waitPodDeleted(ctx context.Context, p v1.Pod) error {
+	cs := getClientSet(ctx)
+
+	for {
+		cur, err := cs.CoreV1().Pods(p.Namespace).Get(ctx, p.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) || (err == nil && cur.UID != p.UID) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// hasEmptyDir Tell whether a pod keeps data in an emptyDir volume
+func hasEmptyDir(p v1.Pod) bool {
+	for _, v := range p.Spec.Volumes {
+		if v.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isDaemonSetPod(p v1.Pod) bool {
+	c := metav1.GetControllerOf(&p)
+	return c != nil && c.Kind == "DaemonSet"
+}
+
+// PodRequests Get the resources requested by a pod, the way the scheduler counts them
+func PodRequests(p v1.Pod) v1.ResourceList {
+	reqs := v1.ResourceList{}
+	for _, c := range p.Spec.Containers {
+		for n, q := range c.Resources.Requests {
+			r := reqs[n]
+			r.Add(q)
+			reqs[n] = r
+		}
+	}
+	for _, c := range p.Spec.InitContainers {
+		for n, q := range c.Resources.Requests {
+			if r, ok := reqs[n]; !ok || q.Cmp(r) > 0 {
+				reqs[n] = q.DeepCopy()
+			}
+		}
+	}
+	if p.Spec.Overhead != nil {
+		for n, q := range p.Spec.Overhead {
+			r := reqs[n]
+			r.Add(q)
+			reqs[n] = r
+		}
+	}
+	return reqs
+}
+
+// NodeRequests Sum the requests of the running pods of each node
+func NodeRequests(pds []v1.Pod) map[string]v1.ResourceList {
+	reqs := map[string]v1.ResourceList{}
+	for _, p := range pds {
+		if p.Spec.NodeName == "" || p.Status.Phase == v1.PodSucceeded || p.Status.Phase == v1.PodFailed {
+			continue
+		}
+		nr, ok := reqs[p.Spec.NodeName]
+		if !ok {
+			nr = v1.ResourceList{}
+			reqs[p.Spec.NodeName] = nr
+		}
+		for n, q := range PodRequests(p) {
+			r := nr[n]
+			r.Add(q)
+			nr[n] = r
+		}
+	}
+	return reqs
+}
+
+// ColumnHelperNodeStatus Column helper: Node status
+func ColumnHelperNodeStatus(n v1.Node) string {
+	s := "NotReady"
+	for _, c := range n.Status.Conditions {
+		if c.Type == v1.NodeReady && c.Status == v1.ConditionTrue {
+			s = "Ready"
+		}
+	}
+	if n.Spec.Unschedulable {
+		s = s + ",SchedulingDisabled"
+	}
+	return s
+}
+
+// ColumnHelperNodeRoles Column helper: Node roles
+func ColumnHelperNodeRoles(n v1.Node) string {
+	var roles []string
+	for l := range n.Labels {
+		if strings.HasPrefix(l, nodeRoleLabelPrefix) {
+			roles = append(roles, strings.TrimPrefix(l, nodeRoleLabelPrefix))
+		}
+	}
+	if len(roles) == 0 {
+		return "<none>"
+	}
+	sort.Strings(roles)
+	return strings.Join(roles, ",")
+}
+
+// ColumnHelperTaints Column helper: Node taints
+func ColumnHelperTaints(n v1.Node) string {
+	var ts []string
+	for _, t := range n.Spec.Taints {
+		ts = append(ts, t.ToString())
+	}
+	if len(ts) == 0 {
+		return "<none>"
+	}
+	return strings.Join(ts, ",")
+}
+
+// ColumnHelperPressure Column helper: Node pressure conditions
+func ColumnHelperPressure(n v1.Node) string {
+	var ps []string
+	for _, c := range n.Status.Conditions {
+		if c.Type != v1.NodeReady && c.Status == v1.ConditionTrue {
+			ps = append(ps, string(c.Type))
+		}
+	}
+	if len(ps) == 0 {
+		return "<none>"
+	}
+	return strings.Join(ps, ",")
+}
+
+// ColumnHelperAllocation Column helper: requested vs allocatable resource
+func ColumnHelperAllocation(name v1.ResourceName, requested v1.ResourceList, allocatable v1.ResourceList) string {
+	req := requested[name]
+	alloc := allocatable[name]
+	pct := int64(0)
+	if alloc.MilliValue() > 0 {
+		pct = req.MilliValue() * 100 / alloc.MilliValue()
+	}
+	return fmt.Sprintf("%s/%s (%d%%)", FormatQuantity(name, req), FormatQuantity(name, alloc), pct)
+}
+
+// FormatQuantity Format cpu in millicores and memory in MiB
+func FormatQuantity(name v1.ResourceName, q resource.Quantity) string {
+	switch name {
+	case v1.ResourceCPU:
+		return fmt.Sprintf("%dm", q.MilliValue())
+	case v1.ResourceMemory:
+		return fmt.Sprintf("%dMi", q.Value()/(1024*1024))
+	default:
+		return q.String()
+	}
+}
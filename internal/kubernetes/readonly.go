@@ -0,0 +1,38 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrReadOnly is returned by every call that would change the cluster of a read-only context
+var ErrReadOnly = errors.New("the context is read-only")
+
+// readOnly holds the kube contexts whose profile is read-only
+var readOnly sync.Map
+
+// SetReadOnly Refuse or allow calls that change the cluster of the kube context in use, it follows the context profile
+func SetReadOnly(ro bool) {
+	readOnly.Store(InUse(), ro)
+}
+
+// ReadOnly Tell whether calls that change the cluster of the kube context in use are refused
+func ReadOnly() bool {
+	if offline != nil {
+		return true
+	}
+	ro, _ := readOnly.Load(InUse())
+	return ro == true
+}
+
+// writable Fail when the context of a call is read-only or a snapshot is open, mutating calls check it before anything else
+func writable(ctx context.Context) error {
+	if offline != nil {
+		return ErrReadOnly
+	}
+	if ro, _ := readOnly.Load(contextOf(ctx)); ro == true {
+		return ErrReadOnly
+	}
+	return nil
+}
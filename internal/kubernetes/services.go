@@ -0,0 +1,148 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"k8s.io/apimachinery/pkg/labels"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetServices Get services (use namespace)
+func GetServices(ctx context.Context, namespace string) ([]v1.Service, error) {
+	cs := getClientSet(ctx)
+
+	svcs, err := cs.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return svcs.Items, nil
+}
+
+// GetEndpointSlices Get endpoint slices grouped by service name (use namespace)
+func GetEndpointSlices(ctx context.Context, namespace string) (map[string][]discoveryv1.EndpointSlice, error) {
+	cs := getClientSet(ctx)
+
+	eps, err := cs.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	bySvc := map[string][]discoveryv1.EndpointSlice{}
+	for _, s := range eps.Items {
+		if svc := s.Labels[discoveryv1.LabelServiceName]; svc != "" {
+			bySvc[svc] = append(bySvc[svc], s)
+		}
+	}
+	return bySvc, nil
+}
+
+// EndpointCounts Count the ready and not ready endpoints of a service, an endpoint listed in
+// several slices, one per address family on dual-stack services, counts once
+func EndpointCounts(slices []discoveryv1.EndpointSlice) (int, int) {
+	ready := map[string]bool{}
+	for _, s := range slices {
+		for _, e := range s.Endpoints {
+			k := endpointKey(e)
+			ready[k] = ready[k] || e.Conditions.Ready == nil || *e.Conditions.Ready
+		}
+	}
+	n := 0
+	for _, r := range ready {
+		if r {
+			n++
+		}
+	}
+	return n, len(ready) - n
+}
+
+// endpointKey Identify an endpoint across slices by what it points to, or its addresses
+func endpointKey(e discoveryv1.Endpoint) string {
+	if r := e.TargetRef; r != nil {
+		return r.Kind + "/" + r.Namespace + "/" + r.Name
+	}
+	if e.Hostname != nil {
+		return "host/" + *e.Hostname
+	}
+	return strings.Join(e.Addresses, ",")
+}
+
+// ServicePodsSelector Label selector matching the pods behind a service, empty when it has none
+func ServicePodsSelector(svc v1.Service) string {
+	if len(svc.Spec.Selector) == 0 {
+		return ""
+	}
+	return labels.SelectorFromSet(svc.Spec.Selector).String()
+}
+
+// ServicesSelectingPod Get the services whose selector matches a pod
+func ServicesSelectingPod(ctx context.Context, p v1.Pod) ([]v1.Service, error) {
+	svcs, err := GetServices(ctx, p.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	var matching []v1.Service
+	for _, svc := range svcs {
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		if labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(p.Labels)) {
+			matching = append(matching, svc)
+		}
+	}
+	return matching, nil
+}
+
+// ColumnHelperPorts Column helper: Service ports
+func ColumnHelperPorts(svc v1.Service) string {
+	var ps []string
+	for _, p := range svc.Spec.Ports {
+		s := fmt.Sprintf("%d/%s", p.Port, p.Protocol)
+		if p.NodePort != 0 {
+			s = fmt.Sprintf("%d:%d/%s", p.Port, p.NodePort, p.Protocol)
+		}
+		ps = append(ps, s)
+	}
+	if len(ps) == 0 {
+		return "<none>"
+	}
+	return strings.Join(ps, ",")
+}
+
+// ColumnHelperExternalIPs Column helper: Service external IPs
+func ColumnHelperExternalIPs(svc v1.Service) string {
+	ips := append([]string{}, svc.Spec.ExternalIPs...)
+	for _, i := range svc.Status.LoadBalancer.Ingress {
+		if i.IP != "" {
+			ips = append(ips, i.IP)
+		} else if i.Hostname != "" {
+			ips = append(ips, i.Hostname)
+		}
+	}
+	if svc.Spec.Type == v1.ServiceTypeExternalName {
+		ips = append(ips, svc.Spec.ExternalName)
+	}
+	if len(ips) == 0 {
+		if svc.Spec.Type == v1.ServiceTypeLoadBalancer {
+			return "<pending>"
+		}
+		return "<none>"
+	}
+	return strings.Join(ips, ",")
+}
+
+// ColumnHelperSelector Column helper: Service selector
+func ColumnHelperSelector(svc v1.Service) string {
+	if len(svc.Spec.Selector) == 0 {
+		return "<none>"
+	}
+	var ss []string
+	for k, v := range svc.Spec.Selector {
+		ss = append(ss, k+"="+v)
+	}
+	sort.Strings(ss)
+	return strings.Join(ss, ",")
+}
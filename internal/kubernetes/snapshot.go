@@ -0,0 +1,403 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd/api"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// snapshotFile describes a snapshot, next to the object lists and the logs directory
+const snapshotFile = "snapshot.json"
+
+// SnapshotInfo tells where and when a snapshot was taken
+type SnapshotInfo struct {
+	Context   string    `json:"context"`
+	Namespace string    `json:"namespace"`
+	Taken     time.Time `json:"taken"`
+	// Skipped are the lists the user was not allowed to read, like nodes for a namespace admin
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// snapshotList is a list a snapshot keeps in a file of its own
+type snapshotList struct {
+	file string
+	kind string
+	// empty is the list type the file decodes to
+	empty func() runtime.Object
+	list  func(ctx context.Context, cs kubernetes.Interface, namespace string) (runtime.Object, error)
+}
+
+var snapshotLists = []snapshotList{
+	{"namespace.json", "Namespace", func() runtime.Object { return &v1.NamespaceList{} },
+		func(ctx context.Context, cs kubernetes.Interface, namespace string) (runtime.Object, error) {
+			sel := fields.OneTermEqualSelector("metadata.name", namespace).String()
+			return cs.CoreV1().Namespaces().List(ctx, metav1.ListOptions{FieldSelector: sel})
+		}},
+	{"pods.json", "Pod", func() runtime.Object { return &v1.PodList{} },
+		func(ctx context.Context, cs kubernetes.Interface, namespace string) (runtime.Object, error) {
+			return cs.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		}},
+	{"events.json", "Event", func() runtime.Object { return &v1.EventList{} },
+		func(ctx context.Context, cs kubernetes.Interface, namespace string) (runtime.Object, error) {
+			return cs.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+		}},
+	{"deployments.json", "Deployment", func() runtime.Object { return &appsv1.DeploymentList{} },
+		func(ctx context.Context, cs kubernetes.Interface, namespace string) (runtime.Object, error) {
+			return cs.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		}},
+	{"statefulsets.json", "StatefulSet", func() runtime.Object { return &appsv1.StatefulSetList{} },
+		func(ctx context.Context, cs kubernetes.Interface, namespace string) (runtime.Object, error) {
+			return cs.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+		}},
+	{"daemonsets.json", "DaemonSet", func() runtime.Object { return &appsv1.DaemonSetList{} },
+		func(ctx context.Context, cs kubernetes.Interface, namespace string) (runtime.Object, error) {
+			return cs.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+		}},
+	{"replicasets.json", "ReplicaSet", func() runtime.Object { return &appsv1.ReplicaSetList{} },
+		func(ctx context.Context, cs kubernetes.Interface, namespace string) (runtime.Object, error) {
+			return cs.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+		}},
+	{"jobs.json", "Job", func() runtime.Object { return &batchv1.JobList{} },
+		func(ctx context.Context, cs kubernetes.Interface, namespace string) (runtime.Object, error) {
+			return cs.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+		}},
+	{"cronjobs.json", "CronJob", func() runtime.Object { return &batchv1.CronJobList{} },
+		func(ctx context.Context, cs kubernetes.Interface, namespace string) (runtime.Object, error) {
+			return cs.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+		}},
+	{"nodes.json", "Node", func() runtime.Object { return &v1.NodeList{} },
+		func(ctx context.Context, cs kubernetes.Interface, _ string) (runtime.Object, error) {
+			return cs.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		}},
+}
+
+// snapshot is an opened dump, every call reads it instead of a cluster
+type snapshot struct {
+	dir  string
+	info SnapshotInfo
+	// client serves the objects of the dump and refuses nothing, writable keeps it unchanged
+	client kubernetes.Interface
+	// objects are the objects of the dump by kind, to list names without a metadata client
+	objects map[string][]runtime.Object
+}
+
+// offline is the snapshot opened with --snapshot, nil when the calls go to a cluster. It is set
+// once before the UI starts and never changes.
+var offline *snapshot
+
+// SnapshotDir Get the directory given with --snapshot, empty to use a cluster
+func SnapshotDir() string {
+	return *snapshotDir
+}
+
+// Snapshot Get the description of the opened snapshot, false when the calls go to a cluster
+func Snapshot() (SnapshotInfo, bool) {
+	if offline == nil {
+		return SnapshotInfo{}, false
+	}
+	return offline.info, true
+}
+
+// SaveSnapshot Write the pods, events, workloads and nodes of a namespace and the last lines of
+// each container log to a directory, for OpenSnapshot to browse later
+func SaveSnapshot(ctx context.Context, dir string, namespace string, tail int64) (SnapshotInfo, error) {
+	info := SnapshotInfo{Context: contextOf(ctx), Namespace: namespace, Taken: time.Now().UTC()}
+	if info.Context == "" {
+		info.Context, _, _ = GetCurrent()
+	}
+	return writeSnapshot(ctx, getClientSet(ctx), dir, info, tail)
+}
+
+// writeSnapshot Write the lists a client gets and the logs of its pods to a directory
+func writeSnapshot(ctx context.Context, cs kubernetes.Interface, dir string, info SnapshotInfo, tail int64) (SnapshotInfo, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return info, err
+	}
+
+	var pods *v1.PodList
+	for _, l := range snapshotLists {
+		list, err := l.list(ctx, cs, info.Namespace)
+		if apierrors.IsForbidden(err) {
+			info.Skipped = append(info.Skipped, l.file)
+			continue
+		}
+		if err != nil {
+			return info, fmt.Errorf("listing %s: %w", strings.TrimSuffix(l.file, ".json"), err)
+		}
+		if err := writeJSON(filepath.Join(dir, l.file), list); err != nil {
+			return info, err
+		}
+		if p, ok := list.(*v1.PodList); ok {
+			pods = p
+		}
+	}
+	if pods != nil {
+		if err := saveLogs(ctx, cs, dir, pods.Items, tail); err != nil {
+			return info, err
+		}
+	}
+	return info, writeJSON(filepath.Join(dir, snapshotFile), info)
+}
+
+// saveLogs Write the last lines of every container, and of its previous run when it restarted.
+// A container that never ran has none and is left out.
+func saveLogs(ctx context.Context, cs kubernetes.Interface, dir string, pds []v1.Pod, tail int64) error {
+	for _, p := range pds {
+		restarts := map[string]int32{}
+		for _, s := range append(append([]v1.ContainerStatus(nil), p.Status.InitContainerStatuses...), p.Status.ContainerStatuses...) {
+			restarts[s.Name] = s.RestartCount
+		}
+		var containers []v1.Container
+		containers = append(containers, p.Spec.InitContainers...)
+		containers = append(containers, p.Spec.Containers...)
+		for _, c := range containers {
+			opts := &v1.PodLogOptions{Container: c.Name}
+			if tail >= 0 {
+				t := tail
+				opts.TailLines = &t
+			}
+			if err := saveLog(ctx, cs, p, opts, snapshotLogPath(dir, p.Namespace, p.Name, c.Name)); err != nil {
+				return err
+			}
+			if restarts[c.Name] == 0 {
+				continue
+			}
+			previous := *opts
+			previous.Previous = true
+			if err := saveLog(ctx, cs, p, &previous, snapshotPreviousLogPath(dir, p.Namespace, p.Name, c.Name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// saveLog Write the logs of a pod to a file, logs the cluster can't give are left out
+func saveLog(ctx context.Context, cs kubernetes.Interface, p v1.Pod, opts *v1.PodLogOptions, path string) error {
+	raw, err := cs.CoreV1().Pods(p.Namespace).GetLogs(p.Name, opts).DoRaw(ctx)
+	if err != nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0o644)
+}
+
+func snapshotLogPath(dir string, namespace string, pod string, container string) string {
+	return filepath.Join(dir, "logs", namespace, pod, container+".log")
+}
+
+// snapshotPreviousLogPath Get the file of the logs of the previous run of a container, a container
+// name has no dot so it never takes the name of another container's logs
+func snapshotPreviousLogPath(dir string, namespace string, pod string, container string) string {
+	return filepath.Join(dir, "logs", namespace, pod, container+".previous.log")
+}
+
+func writeJSON(path string, v interface{}) error {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(raw, '\n'), 0o644)
+}
+
+// OpenSnapshot Serve every call from a dump written by SaveSnapshot, the cluster is never called
+// and calls that would change it fail with ErrReadOnly
+func OpenSnapshot(dir string) error {
+	s := &snapshot{dir: dir, objects: map[string][]runtime.Object{}}
+	raw, err := os.ReadFile(filepath.Join(dir, snapshotFile))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, &s.info); err != nil {
+		return fmt.Errorf("%s: %w", snapshotFile, err)
+	}
+
+	var objects []runtime.Object
+	for _, l := range snapshotLists {
+		raw, err := os.ReadFile(filepath.Join(dir, l.file))
+		if errors.Is(err, os.ErrNotExist) {
+			// Skipped when the snapshot was taken
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		list := l.empty()
+		if err := json.Unmarshal(raw, list); err != nil {
+			return fmt.Errorf("%s: %w", l.file, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return fmt.Errorf("%s: %w", l.file, err)
+		}
+		s.objects[l.kind] = items
+		objects = append(objects, items...)
+	}
+
+	cs := fake.NewClientset(objects...)
+	// The fake client only filters on labels, node pods and object events need field selectors
+	react := k8stesting.ObjectReaction(cs.Tracker())
+	cs.PrependReactor("list", "*", func(a k8stesting.Action) (bool, runtime.Object, error) {
+		la, ok := a.(k8stesting.ListAction)
+		if !ok || la.GetListRestrictions().Fields == nil || la.GetListRestrictions().Fields.Empty() {
+			return false, nil, nil
+		}
+		handled, list, err := react(a)
+		if !handled || err != nil {
+			return handled, list, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return true, nil, err
+		}
+		var kept []runtime.Object
+		for _, o := range items {
+			if la.GetListRestrictions().Fields.Matches(objectFields(o)) {
+				kept = append(kept, o)
+			}
+		}
+		return true, list, meta.SetList(list, kept)
+	})
+	s.client = cs
+
+	NewMetricsClient = func(context.Context) MetricsClient { return snapshotMetrics{} }
+	offline = s
+	return nil
+}
+
+// objectFields Get the fields a field selector can match on an object of the snapshot
+func objectFields(o runtime.Object) fields.Set {
+	set := fields.Set{}
+	if m, err := meta.Accessor(o); err == nil {
+		set["metadata.name"] = m.GetName()
+		set["metadata.namespace"] = m.GetNamespace()
+	}
+	switch o := o.(type) {
+	case *v1.Pod:
+		set["spec.nodeName"] = o.Spec.NodeName
+		set["status.phase"] = string(o.Status.Phase)
+	case *v1.Event:
+		set["involvedObject.kind"] = o.InvolvedObject.Kind
+		set["involvedObject.name"] = o.InvolvedObject.Name
+		set["involvedObject.namespace"] = o.InvolvedObject.Namespace
+		set["involvedObject.uid"] = string(o.InvolvedObject.UID)
+		set["reason"] = o.Reason
+		set["type"] = o.Type
+	}
+	return set
+}
+
+// snapshotMetrics has no usage, a snapshot keeps none
+type snapshotMetrics struct{}
+
+func (snapshotMetrics) PodMetrics(context.Context, string, metav1.ListOptions) ([]metricsv1beta1.PodMetrics, error) {
+	return nil, ErrMetricsUnavailable
+}
+
+func (snapshotMetrics) NodeMetrics(context.Context) ([]metricsv1beta1.NodeMetrics, error) {
+	return nil, ErrMetricsUnavailable
+}
+
+// contexts Get the only context of the snapshot, named after the context it was taken from
+func (s *snapshot) contexts() map[string]*api.Context {
+	c := api.NewContext()
+	c.Cluster = s.info.Context
+	c.Namespace = s.info.Namespace
+	return map[string]*api.Context{s.info.Context: c}
+}
+
+// names Get the names of the objects of a kind in the snapshot (use namespace)
+func (s *snapshot) names(kind string, namespace string) []string {
+	var names []string
+	for _, o := range s.objects[kind] {
+		m, err := meta.Accessor(o)
+		if err != nil || (namespace != "" && !clusterScoped[kind] && m.GetNamespace() != namespace) {
+			continue
+		}
+		names = append(names, m.GetName())
+	}
+	return names
+}
+
+// logs Open the saved log of a container, an empty container is the default one of the pod
+func (s *snapshot) logs(ctx context.Context, namespace string, pod string, container string) (io.ReadCloser, error) {
+	if container == "" {
+		p, err := s.client.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		container = DefaultContainer(*p)
+	}
+	f, err := os.Open(snapshotLogPath(s.dir, namespace, pod, container))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("the snapshot has no logs for %s/%s container %s", namespace, pod, container)
+	}
+	return f, err
+}
+
+// previousLogs Get the last tail lines saved of the previous run of a container
+func (s *snapshot) previousLogs(namespace string, pod string, container string, tail int) (string, error) {
+	raw, err := os.ReadFile(snapshotPreviousLogPath(s.dir, namespace, pod, container))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("the snapshot has no previous logs for %s/%s container %s", namespace, pod, container)
+	}
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(strings.TrimRight(string(raw), "\n"), "\n")
+	if len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// snapshotLogs Send the saved lines of a container, the last tail ones when tail isn't negative.
+// Following ends with the file, a snapshot gets no new lines.
+func snapshotLogs(ctx context.Context, namespace string, pod string, container string, tail int64, logChan chan<- string) error {
+	f, err := offline.logs(ctx, namespace, pod, container)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	raw, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	lines := strings.SplitAfter(string(raw), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if tail >= 0 && int64(len(lines)) > tail {
+		lines = lines[int64(len(lines))-tail:]
+	}
+	for _, line := range lines {
+		select {
+		case logChan <- line:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// openTestSnapshot Save a namespace of a fake cluster and open it, the snapshot is closed when the test ends
+func openTestSnapshot(t *testing.T, pods ...v1.Pod) string {
+	events := []v1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "api.1", Namespace: "shop"}, Reason: "BackOff",
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "api", Namespace: "shop"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web.1", Namespace: "shop"}, Reason: "Pulled",
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "web", Namespace: "shop"}},
+	}
+	cs := fake.NewClientset()
+	for _, p := range pods {
+		if _, err := cs.CoreV1().Pods(p.Namespace).Create(context.Background(), &p, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, e := range events {
+		if _, err := cs.CoreV1().Events(e.Namespace).Create(context.Background(), &e, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir := t.TempDir()
+	if _, err := writeSnapshot(context.Background(), cs, dir, SnapshotInfo{Context: "prod", Namespace: "shop"}, -1); err != nil {
+		t.Fatal(err)
+	}
+	old := NewMetricsClient
+	t.Cleanup(func() {
+		offline = nil
+		NewMetricsClient = old
+	})
+	if err := OpenSnapshot(dir); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func testPod(name string, node string, restarts int32) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Labels: map[string]string{"app": name}},
+		Spec:       v1.PodSpec{NodeName: node, Containers: []v1.Container{{Name: "app"}}},
+		Status:     v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{Name: "app", RestartCount: restarts}}},
+	}
+}
+
+func podNames(pds []v1.Pod) []string {
+	var names []string
+	for _, p := range pds {
+		names = append(names, p.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestSnapshot(t *testing.T) {
+	dir := openTestSnapshot(t, testPod("api", "node-1", 3), testPod("web", "node-2", 0), testPod("worker", "node-1", 0))
+	ctx := context.Background()
+
+	t.Run("pods by label", func(t *testing.T) {
+		pds, err := GetPodsWithSelector(ctx, "shop", "app=web")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := podNames(pds); !slices.Equal(got, []string{"web"}) {
+			t.Errorf("pods = %v, want [web]", got)
+		}
+	})
+	t.Run("pods by field", func(t *testing.T) {
+		pds, err := GetNodePods(ctx, "node-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := podNames(pds); !slices.Equal(got, []string{"api", "worker"}) {
+			t.Errorf("pods = %v, want [api worker]", got)
+		}
+	})
+	t.Run("events of an object", func(t *testing.T) {
+		evs, err := GetObjectEvents(ctx, "shop", "Pod", "api")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(evs) != 1 || evs[0].Reason != "BackOff" {
+			t.Errorf("events = %+v, want the BackOff of api", evs)
+		}
+	})
+	t.Run("previous logs of restarted containers", func(t *testing.T) {
+		if _, err := os.Stat(snapshotPreviousLogPath(dir, "shop", "api", "app")); err != nil {
+			t.Errorf("api restarted, want its previous logs: %v", err)
+		}
+		if _, err := os.Stat(snapshotPreviousLogPath(dir, "shop", "web", "app")); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("web never restarted, want no previous logs: %v", err)
+		}
+		if _, err := GetPreviousLogs(ctx, "shop", "api", "app"); err != nil {
+			t.Errorf("GetPreviousLogs = %v", err)
+		}
+	})
+	t.Run("read-only", func(t *testing.T) {
+		if err := DeletePod(ctx, "shop", "api"); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("DeletePod = %v, want ErrReadOnly", err)
+		}
+		if _, err := GetPod(ctx, "shop", "api"); err != nil {
+			t.Errorf("the pod is gone after a refused delete: %v", err)
+		}
+	})
+}
+
+func TestSnapshotLogs(t *testing.T) {
+	dir := openTestSnapshot(t, testPod("api", "node-1", 0))
+	// The fake cluster has a single line of logs, the saved file stands for a longer one
+	path := snapshotLogPath(dir, "shop", "api", "app")
+	if err := os.WriteFile(path, []byte("one\ntwo\nthree\nfour\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		tail int64
+		want string
+	}{
+		{-1, "one\ntwo\nthree\nfour\n"},
+		{2, "three\nfour\n"},
+		{10, "one\ntwo\nthree\nfour\n"},
+		{0, ""},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := WritePodLogs(context.Background(), "shop", "api", LogOptions{Tail: tt.tail}, &b); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("logs with tail %d = %q, want %q", tt.tail, b.String(), tt.want)
+		}
+	}
+	if err := WritePodLogs(context.Background(), "shop", "api", LogOptions{Container: "sidecar", Tail: -1}, &strings.Builder{}); err == nil {
+		t.Error("logs of a container the snapshot has none of, want an error")
+	}
+}
@@ -0,0 +1,142 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetPVCs Get persistent volume claims (use namespace)
+func GetPVCs(ctx context.Context, namespace string) ([]v1.PersistentVolumeClaim, error) {
+	cs := getClientSet(ctx)
+
+	pvcs, err := cs.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return pvcs.Items, nil
+}
+
+// GetPVs Get persistent volumes
+func GetPVs(ctx context.Context) ([]v1.PersistentVolume, error) {
+	cs := getClientSet(ctx)
+
+	pvs, err := cs.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return pvs.Items, nil
+}
+
+// GetStorageClasses Get storage classes
+func GetStorageClasses(ctx context.Context) ([]storagev1.StorageClass, error) {
+	cs := getClientSet(ctx)
+
+	scs, err := cs.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return scs.Items, nil
+}
+
+// PodsMountingPVC Get the pods with a volume using a claim, generic ephemeral volumes included
+func PodsMountingPVC(pds []v1.Pod, claim string) []v1.Pod {
+	var mounting []v1.Pod
+	for _, p := range pds {
+		for _, v := range p.Spec.Volumes {
+			switch {
+			case v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == claim:
+			case v.Ephemeral != nil && p.Name+"-"+v.Name == claim:
+			default:
+				continue
+			}
+			mounting = append(mounting, p)
+			break
+		}
+	}
+	return mounting
+}
+
+// CanExpand Tell whether the storage class of a claim allows volume expansion
+func CanExpand(pvc v1.PersistentVolumeClaim, classes []storagev1.StorageClass) bool {
+	if pvc.Spec.StorageClassName == nil {
+		return false
+	}
+	for _, sc := range classes {
+		if sc.Name == *pvc.Spec.StorageClassName {
+			return sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion
+		}
+	}
+	return false
+}
+
+// ResizePVC Patch the storage request of a claim, it can only grow
+func ResizePVC(ctx context.Context, pvc v1.PersistentVolumeClaim, size string) error {
+	if err := writable(ctx); err != nil {
+		return err
+	}
+	cs := getClientSet(ctx)
+
+	q, err := resource.ParseQuantity(size)
+	if err != nil {
+		return fmt.Errorf("invalid size %q: %w", size, err)
+	}
+	cur := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+	if q.Cmp(cur) <= 0 {
+		return fmt.Errorf("new size %s must be larger than %s", q.String(), cur.String())
+	}
+	patch := fmt.Sprintf(`{"spec":{"resources":{"requests":{"storage":%q}}}}`, q.String())
+	_, err = cs.CoreV1().PersistentVolumeClaims(pvc.Namespace).Patch(ctx, pvc.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	return err
+}
+
+// ColumnHelperAccessModes Column helper: Access modes
+func ColumnHelperAccessModes(modes []v1.PersistentVolumeAccessMode) string {
+	var ms []string
+	for _, m := range modes {
+		switch m {
+		case v1.ReadWriteOnce:
+			ms = append(ms, "RWO")
+		case v1.ReadOnlyMany:
+			ms = append(ms, "ROX")
+		case v1.ReadWriteMany:
+			ms = append(ms, "RWX")
+		case v1.ReadWriteOncePod:
+			ms = append(ms, "RWOP")
+		default:
+			ms = append(ms, string(m))
+		}
+	}
+	return strings.Join(ms, ",")
+}
+
+// ColumnHelperCapacity Column helper: Storage capacity
+func ColumnHelperCapacity(rl v1.ResourceList) string {
+	q, ok := rl[v1.ResourceStorage]
+	if !ok {
+		return ""
+	}
+	return q.String()
+}
+
+// ColumnHelperClaim Column helper: Claim bound to a volume
+func ColumnHelperClaim(pv v1.PersistentVolume) string {
+	if pv.Spec.ClaimRef == nil {
+		return ""
+	}
+	return pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+}
+
+// ColumnHelperStorageClass Column helper: Storage class name
+func ColumnHelperStorageClass(name *string) string {
+	if name == nil || *name == "" {
+		return "<unset>"
+	}
+	return *name
+}
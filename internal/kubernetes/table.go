@@ -0,0 +1,189 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// tableAccept asks the API server to print the list as a meta.k8s.io Table and
+// falls back to plain JSON when the resource has no table convertor
+const tableAccept = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json"
+
+// ErrTableUnsupported is returned when the server does not answer with a Table
+var ErrTableUnsupported = errors.New("server does not support table output")
+
+// Resource identifies a listable API resource
+type Resource struct {
+	Group      string
+	Version    string
+	Name       string
+	Kind       string
+	Namespaced bool
+}
+
+var PodResource = Resource{Version: "v1", Name: "pods", Kind: "Pod", Namespaced: true}
+
+// FindResource Find the API resource of a name like kubectl get does: plural, singular, short name
+// or kind, with an optional group like certificates.cert-manager.io. Custom resources are found too.
+func FindResource(ctx context.Context, name string) (Resource, error) {
+	lists, err := getClientSet(ctx).Discovery().ServerPreferredResources()
+	// An aggregated API that is down fails discovery of its group only, the others are there
+	if len(lists) == 0 && err != nil {
+		return Resource{}, err
+	}
+	name, group, _ := strings.Cut(strings.ToLower(name), ".")
+	for _, l := range lists {
+		gv, err := schema.ParseGroupVersion(l.GroupVersion)
+		if err != nil || (group != "" && gv.Group != group) {
+			continue
+		}
+		for _, r := range l.APIResources {
+			// Subresources like pods/log can't be listed
+			if strings.Contains(r.Name, "/") || !slices.Contains(r.Verbs, "list") {
+				continue
+			}
+			if r.Name == name || r.SingularName == name || strings.ToLower(r.Kind) == name || slices.Contains(r.ShortNames, name) {
+				return Resource{Group: gv.Group, Version: gv.Version, Name: r.Name, Kind: r.Kind, Namespaced: r.Namespaced}, nil
+			}
+		}
+	}
+	return Resource{}, fmt.Errorf("the server has no resource %q", name)
+}
+
+// TableObject Get the metadata of the object carried by a table row
+func TableObject(row metav1.TableRow) (metav1.PartialObjectMetadata, error) {
+	var o metav1.PartialObjectMetadata
+	err := json.Unmarshal(row.Object.Raw, &o)
+	return o, err
+}
+
+// path Build the list URL of the resource (use namespace)
+func (r Resource) path(namespace string) string {
+	p := "/api/" + r.Version
+	if r.Group != "" {
+		p = "/apis/" + r.Group + "/" + r.Version
+	}
+	if r.Namespaced && namespace != "" {
+		p = p + "/namespaces/" + namespace
+	}
+	return p + "/" + r.Name
+}
+
+// GetTable Get resources printed by the server, the same columns as kubectl get
+func GetTable(ctx context.Context, r Resource, namespace string, opts metav1.ListOptions) (*metav1.Table, error) {
+	if offline != nil {
+		// A snapshot has no server to print tables, they are built locally
+		return nil, ErrTableUnsupported
+	}
+	cs := getClientSet(ctx)
+
+	raw, err := cs.Discovery().RESTClient().Get().
+		AbsPath(r.path(namespace)).
+		SetHeader("Accept", tableAccept).
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Param("includeObject", string(metav1.IncludeObject)).
+		Do(ctx).
+		Raw()
+	if err != nil {
+		if apierrors.IsNotAcceptable(err) || apierrors.IsUnsupportedMediaType(err) {
+			return nil, ErrTableUnsupported
+		}
+		return nil, err
+	}
+
+	t := &metav1.Table{}
+	if err := json.Unmarshal(raw, t); err != nil {
+		return nil, err
+	}
+	if t.Kind != "Table" {
+		return nil, ErrTableUnsupported
+	}
+	return t, nil
+}
+
+// GetPodTable Get pods as a table, printed locally when the server can't
+func GetPodTable(ctx context.Context, namespace string, opts metav1.ListOptions) (*metav1.Table, error) {
+	t, err := GetTable(ctx, PodResource, namespace, opts)
+	if errors.Is(err, ErrTableUnsupported) {
+		cs := getClientSet(ctx)
+		pds, err := cs.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return PodTable(pds.Items), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range t.Rows {
+		p := &v1.Pod{}
+		if err := json.Unmarshal(t.Rows[i].Object.Raw, p); err != nil {
+			return nil, fmt.Errorf("decoding pod row %d: %w", i, err)
+		}
+		t.Rows[i].Object.Object = p
+	}
+	return t, nil
+}
+
+// PodTable Build a pod table with the column helpers
+func PodTable(pds []v1.Pod) *metav1.Table {
+	t := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Ready", Type: "string"},
+			{Name: "Status", Type: "string"},
+			{Name: "Restarts", Type: "string"},
+			{Name: "Age", Type: "string"},
+			{Name: "IP", Type: "string", Priority: 1},
+			{Name: "Node", Type: "string", Priority: 1},
+		},
+	}
+	for i := range pds {
+		p := &pds[i]
+		t.Rows = append(t.Rows, metav1.TableRow{
+			Cells: []interface{}{
+				p.Name,
+				ColumnHelperReady(p.Status.ContainerStatuses),
+				PodStatusReason(*p),
+				ColumnHelperRestarts(p.Status.ContainerStatuses),
+				ColumnHelperAge(p.CreationTimestamp),
+				p.Status.PodIP,
+				p.Spec.NodeName,
+			},
+			Object: runtime.RawExtension{Object: p},
+		})
+	}
+	return t
+}
+
+// TablePod Get the pod carried by a table row
+func TablePod(row metav1.TableRow) (*v1.Pod, bool) {
+	p, ok := row.Object.Object.(*v1.Pod)
+	return p, ok
+}
+
+// TableCell Render a table cell as text
+func TableCell(c interface{}) string {
+	switch v := c.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,33 @@
+package kubernetes
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// retryWatch Watch from the resource version of a list, the API server ends watches every few
+// minutes and the watch picks up again from the last version it saw. It ends only when that
+// version is too old to resume from.
+func retryWatch(ctx context.Context, list func(ctx context.Context, opts metav1.ListOptions) (metav1.ListInterface, error),
+	watchFunc func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error), opts metav1.ListOptions) (watch.Interface, error) {
+	// Watching again later must reach the cluster the watch started on, whatever tab is shown then
+	ctx = context.WithValue(ctx, boundKey{}, contextOf(ctx))
+	if offline != nil {
+		// A snapshot never changes, its watch has no version to resume from
+		return watchFunc(ctx, opts)
+	}
+	l, err := list(ctx, metav1.ListOptions{LabelSelector: opts.LabelSelector, FieldSelector: opts.FieldSelector, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	return watchtools.NewRetryWatcherWithContext(ctx, l.GetResourceVersion(), &cache.ListWatch{
+		WatchFuncWithContext: func(ctx context.Context, o metav1.ListOptions) (watch.Interface, error) {
+			o.LabelSelector, o.FieldSelector = opts.LabelSelector, opts.FieldSelector
+			return watchFunc(ctx, o)
+		},
+	})
+}
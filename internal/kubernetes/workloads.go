@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkloadKinds are the controllers the workloads view lists
+var WorkloadKinds = []string{"Deployment", "StatefulSet", "DaemonSet"}
+
+// Workload is a controller with its rollout counts, like kubectl get deploy shows
+type Workload struct {
+	Namespace string
+	Name      string
+	Ready     int32
+	Desired   int32
+	UpToDate  int32
+	Available int32
+	Created   metav1.Time
+}
+
+// GetWorkloads Get the controllers of a kind (use namespace)
+func GetWorkloads(ctx context.Context, kind string, namespace string) ([]Workload, error) {
+	cs := getClientSet(ctx)
+
+	var ws []Workload
+	switch kind {
+	case "Deployment":
+		ds, err := cs.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range ds.Items {
+			ws = append(ws, Workload{d.Namespace, d.Name, d.Status.ReadyReplicas, replicas(d.Spec.Replicas), d.Status.UpdatedReplicas, d.Status.AvailableReplicas, d.CreationTimestamp})
+		}
+	case "StatefulSet":
+		ss, err := cs.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range ss.Items {
+			ws = append(ws, Workload{s.Namespace, s.Name, s.Status.ReadyReplicas, replicas(s.Spec.Replicas), s.Status.UpdatedReplicas, s.Status.AvailableReplicas, s.CreationTimestamp})
+		}
+	case "DaemonSet":
+		ds, err := cs.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range ds.Items {
+			ws = append(ws, Workload{d.Namespace, d.Name, d.Status.NumberReady, d.Status.DesiredNumberScheduled, d.Status.UpdatedNumberScheduled, d.Status.NumberAvailable, d.CreationTimestamp})
+		}
+	default:
+		return nil, fmt.Errorf("%s is not a workload kind", kind)
+	}
+	return ws, nil
+}
+
+// ColumnHelperWorkloadReady Column helper: Ready, like 2/3
+func ColumnHelperWorkloadReady(w Workload) string {
+	return fmt.Sprintf("%d/%d", w.Ready, w.Desired)
+}
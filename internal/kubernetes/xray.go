@@ -0,0 +1,513 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxOwnerDepth stops following owner references that loop
+const maxOwnerDepth = 8
+
+// Health is the state a resource tree node is drawn with
+type Health uint8
+
+const (
+	HealthOK Health = iota
+	HealthWarning
+	HealthError
+)
+
+// ObjectRef points at a namespaced object
+type ObjectRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (r ObjectRef) String() string {
+	return r.Kind + "/" + r.Name
+}
+
+// XrayNode is a resource in the tree of what a workload owns and references
+type XrayNode struct {
+	ObjectRef
+	Status   string
+	Health   Health
+	Children []*XrayNode
+}
+
+// controllerRef Get the managing owner, the first owner when none is marked as controller
+func controllerRef(refs []metav1.OwnerReference) *metav1.OwnerReference {
+	for i := range refs {
+		if refs[i].Controller != nil && *refs[i].Controller {
+			return &refs[i]
+		}
+	}
+	if len(refs) > 0 {
+		return &refs[0]
+	}
+	return nil
+}
+
+// OwnerChain Follow the controller owner references of an object up, nearest owner first
+func OwnerChain(ctx context.Context, namespace string, refs []metav1.OwnerReference) ([]ObjectRef, error) {
+	cs := getClientSet(ctx)
+
+	var chain []ObjectRef
+	for i := 0; i < maxOwnerDepth; i++ {
+		ref := controllerRef(refs)
+		if ref == nil {
+			break
+		}
+		chain = append(chain, ObjectRef{Kind: ref.Kind, Namespace: namespace, Name: ref.Name})
+
+		var meta metav1.Object
+		var err error
+		switch ref.Kind {
+		case "ReplicaSet":
+			meta, err = cs.AppsV1().ReplicaSets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		case "Deployment":
+			meta, err = cs.AppsV1().Deployments(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		case "StatefulSet":
+			meta, err = cs.AppsV1().StatefulSets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		case "DaemonSet":
+			meta, err = cs.AppsV1().DaemonSets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		case "Job":
+			meta, err = cs.BatchV1().Jobs(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		case "CronJob":
+			meta, err = cs.BatchV1().CronJobs(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		default:
+			// Owners we don't know how to get, like custom resources, end the chain
+			return chain, nil
+		}
+		if apierrors.IsNotFound(err) {
+			return chain, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		refs = meta.GetOwnerReferences()
+	}
+	return chain, nil
+}
+
+// Xray Build the tree of what a workload or pod owns and references
+func Xray(ctx context.Context, ref ObjectRef) (*XrayNode, error) {
+	cs := getClientSet(ctx)
+	ns := ref.Namespace
+
+	switch ref.Kind {
+	case "Deployment":
+		d, err := cs.AppsV1().Deployments(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		n := replicasNode(ref, d.Status.ReadyReplicas, replicas(d.Spec.Replicas))
+		rss, err := cs.AppsV1().ReplicaSets(ns).List(ctx, selectorOptions(d.Spec.Selector))
+		if err != nil {
+			return nil, err
+		}
+		pds, err := podsOf(ctx, ns, d.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, rs := range ownedBy(rss.Items, d.UID, func(rs appsv1.ReplicaSet) metav1.Object { return &rs }) {
+			children := podNodes(ownedPods(pds, rs.UID))
+			// Old revisions scaled to zero only add noise
+			if replicas(rs.Spec.Replicas) == 0 && len(children) == 0 {
+				continue
+			}
+			rn := replicasNode(ObjectRef{Kind: "ReplicaSet", Namespace: ns, Name: rs.Name}, rs.Status.ReadyReplicas, replicas(rs.Spec.Replicas))
+			rn.Children = children
+			n.Children = append(n.Children, rn)
+		}
+		return n, addSpecRefs(ctx, n, ns, d.Spec.Template)
+	case "ReplicaSet":
+		rs, err := cs.AppsV1().ReplicaSets(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		n := replicasNode(ref, rs.Status.ReadyReplicas, replicas(rs.Spec.Replicas))
+		return n, addOwnedPods(ctx, n, rs.UID, rs.Spec.Selector, rs.Spec.Template)
+	case "StatefulSet":
+		sts, err := cs.AppsV1().StatefulSets(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		n := replicasNode(ref, sts.Status.ReadyReplicas, replicas(sts.Spec.Replicas))
+		if err := addOwnedPods(ctx, n, sts.UID, sts.Spec.Selector, sts.Spec.Template); err != nil {
+			return nil, err
+		}
+		// Claims made from the volume claim templates are named <template>-<pod>
+		for _, c := range n.Children {
+			if c.Kind != "Pod" {
+				continue
+			}
+			for _, t := range sts.Spec.VolumeClaimTemplates {
+				pn, err := pvcNode(ctx, ns, t.Name+"-"+c.Name)
+				if err != nil {
+					return nil, err
+				}
+				c.Children = append(c.Children, pn)
+			}
+		}
+		return n, nil
+	case "DaemonSet":
+		ds, err := cs.AppsV1().DaemonSets(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		n := replicasNode(ref, ds.Status.NumberReady, ds.Status.DesiredNumberScheduled)
+		return n, addOwnedPods(ctx, n, ds.UID, ds.Spec.Selector, ds.Spec.Template)
+	case "Job":
+		job, err := cs.BatchV1().Jobs(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		n := jobNode(*job)
+		return n, addOwnedPods(ctx, n, job.UID, job.Spec.Selector, job.Spec.Template)
+	case "CronJob":
+		cj, err := cs.BatchV1().CronJobs(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		n := &XrayNode{ObjectRef: ref, Status: "Active"}
+		if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+			n.Status, n.Health = "Suspended", HealthWarning
+		}
+		jobs, err := GetJobs(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range ownedBy(jobs, cj.UID, func(j batchv1.Job) metav1.Object { return &j }) {
+			jn := jobNode(job)
+			pds, err := podsOf(ctx, ns, job.Spec.Selector)
+			if err != nil {
+				return nil, err
+			}
+			jn.Children = podNodes(ownedPods(pds, job.UID))
+			n.Children = append(n.Children, jn)
+		}
+		return n, addSpecRefs(ctx, n, ns, cj.Spec.JobTemplate.Spec.Template)
+	case "Pod":
+		p, err := cs.CoreV1().Pods(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		n := podNodes([]v1.Pod{*p})[0]
+		return n, addSpecRefs(ctx, n, ns, v1.PodTemplateSpec{ObjectMeta: p.ObjectMeta, Spec: p.Spec})
+	default:
+		return nil, fmt.Errorf("xray of %s is not supported", ref.Kind)
+	}
+}
+
+func replicas(r *int32) int32 {
+	if r == nil {
+		return 1
+	}
+	return *r
+}
+
+// replicasNode Build a node that is healthy when every replica is ready
+func replicasNode(ref ObjectRef, ready int32, desired int32) *XrayNode {
+	n := &XrayNode{ObjectRef: ref, Status: fmt.Sprintf("%d/%d ready", ready, desired)}
+	switch {
+	case ready >= desired:
+	case ready == 0:
+		n.Health = HealthError
+	default:
+		n.Health = HealthWarning
+	}
+	return n
+}
+
+func jobNode(job batchv1.Job) *XrayNode {
+	n := &XrayNode{ObjectRef: ObjectRef{Kind: "Job", Namespace: job.Namespace, Name: job.Name}, Status: ColumnHelperJobStatus(job)}
+	if JobFailed(job) {
+		n.Health = HealthError
+	}
+	return n
+}
+
+func selectorOptions(s *metav1.LabelSelector) metav1.ListOptions {
+	sel, err := metav1.LabelSelectorAsSelector(s)
+	if err != nil {
+		return metav1.ListOptions{}
+	}
+	return metav1.ListOptions{LabelSelector: sel.String()}
+}
+
+func podsOf(ctx context.Context, namespace string, s *metav1.LabelSelector) ([]v1.Pod, error) {
+	return GetPodsWithSelector(ctx, namespace, selectorOptions(s).LabelSelector)
+}
+
+// ownedBy Keep the objects whose controller is the owner
+func ownedBy[T any](items []T, owner types.UID, meta func(T) metav1.Object) []T {
+	var owned []T
+	for _, i := range items {
+		if ref := controllerRef(meta(i).GetOwnerReferences()); ref != nil && ref.UID == owner {
+			owned = append(owned, i)
+		}
+	}
+	return owned
+}
+
+func ownedPods(pds []v1.Pod, owner types.UID) []v1.Pod {
+	return ownedBy(pds, owner, func(p v1.Pod) metav1.Object { return &p })
+}
+
+// addOwnedPods Add the pods of a controller and what its pod template references
+func addOwnedPods(ctx context.Context, n *XrayNode, owner types.UID, s *metav1.LabelSelector, t v1.PodTemplateSpec) error {
+	pds, err := podsOf(ctx, n.Namespace, s)
+	if err != nil {
+		return err
+	}
+	n.Children = append(n.Children, podNodes(ownedPods(pds, owner))...)
+	return addSpecRefs(ctx, n, n.Namespace, t)
+}
+
+// podNodes Build pod nodes with one child per container
+func podNodes(pds []v1.Pod) []*XrayNode {
+	var nodes []*XrayNode
+	for _, p := range pds {
+		n := &XrayNode{ObjectRef: ObjectRef{Kind: "Pod", Namespace: p.Namespace, Name: p.Name}, Status: PodStatusReason(p)}
+		statuses := map[string]v1.ContainerStatus{}
+		for _, c := range p.Status.ContainerStatuses {
+			statuses[c.Name] = c
+		}
+		for _, c := range p.Spec.Containers {
+			cn := containerNode(p.Namespace, c.Name, statuses[c.Name])
+			n.Health = max(n.Health, cn.Health)
+			n.Children = append(n.Children, cn)
+		}
+		switch p.Status.Phase {
+		case v1.PodFailed:
+			n.Health = HealthError
+		case v1.PodPending:
+			n.Health = max(n.Health, HealthWarning)
+		case v1.PodSucceeded:
+			n.Health = HealthOK
+		default:
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+func containerNode(namespace string, name string, s v1.ContainerStatus) *XrayNode {
+	n := &XrayNode{ObjectRef: ObjectRef{Kind: "Container", Namespace: namespace, Name: name}}
+	switch {
+	case s.State.Waiting != nil:
+		n.Status = s.State.Waiting.Reason
+		n.Health = HealthWarning
+		switch s.State.Waiting.Reason {
+		case "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "CreateContainerConfigError", "InvalidImageName":
+			n.Health = HealthError
+		default:
+		}
+	case s.State.Terminated != nil:
+		n.Status = s.State.Terminated.Reason
+		if s.State.Terminated.ExitCode != 0 {
+			n.Health = HealthError
+		}
+	case s.State.Running != nil && !s.Ready:
+		n.Status = "Running, not ready"
+		n.Health = HealthWarning
+	case s.State.Running != nil:
+		n.Status = "Running"
+	default:
+		n.Status = "Unknown"
+		n.Health = HealthWarning
+	}
+	if s.RestartCount > 0 {
+		n.Status = fmt.Sprintf("%s, %d restarts", n.Status, s.RestartCount)
+	}
+	return n
+}
+
+// specRefs is what a pod spec references by name
+type specRefs struct {
+	configMaps map[string]bool
+	secrets    map[string]bool
+	claims     []string
+}
+
+// collectSpecRefs Gather the config maps, secrets and claims used in volumes and env,
+// the value tells whether the reference is optional
+func collectSpecRefs(spec v1.PodSpec) specRefs {
+	r := specRefs{configMaps: map[string]bool{}, secrets: map[string]bool{}}
+	addCM := func(name string, optional *bool) {
+		r.configMaps[name] = r.configMaps[name] || (optional != nil && *optional)
+	}
+	addSecret := func(name string, optional *bool) {
+		r.secrets[name] = r.secrets[name] || (optional != nil && *optional)
+	}
+	for _, vol := range spec.Volumes {
+		switch {
+		case vol.ConfigMap != nil:
+			addCM(vol.ConfigMap.Name, vol.ConfigMap.Optional)
+		case vol.Secret != nil:
+			addSecret(vol.Secret.SecretName, vol.Secret.Optional)
+		case vol.PersistentVolumeClaim != nil:
+			r.claims = append(r.claims, vol.PersistentVolumeClaim.ClaimName)
+		case vol.Projected != nil:
+			for _, s := range vol.Projected.Sources {
+				if s.ConfigMap != nil {
+					addCM(s.ConfigMap.Name, s.ConfigMap.Optional)
+				}
+				if s.Secret != nil {
+					addSecret(s.Secret.Name, s.Secret.Optional)
+				}
+			}
+		default:
+		}
+	}
+	containers := append(append([]v1.Container(nil), spec.InitContainers...), spec.Containers...)
+	for _, c := range containers {
+		for _, e := range c.EnvFrom {
+			if e.ConfigMapRef != nil {
+				addCM(e.ConfigMapRef.Name, e.ConfigMapRef.Optional)
+			}
+			if e.SecretRef != nil {
+				addSecret(e.SecretRef.Name, e.SecretRef.Optional)
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if k := e.ValueFrom.ConfigMapKeyRef; k != nil {
+				addCM(k.Name, k.Optional)
+			}
+			if k := e.ValueFrom.SecretKeyRef; k != nil {
+				addSecret(k.Name, k.Optional)
+			}
+		}
+	}
+	for _, s := range spec.ImagePullSecrets {
+		addSecret(s.Name, nil)
+	}
+	return r
+}
+
+// addSpecRefs Add the service account, config maps, secrets and claims a pod template
+// uses and the services selecting its pods
+func addSpecRefs(ctx context.Context, n *XrayNode, namespace string, t v1.PodTemplateSpec) error {
+	cs := getClientSet(ctx)
+	refs := collectSpecRefs(t.Spec)
+
+	sa := t.Spec.ServiceAccountName
+	if sa == "" {
+		sa = "default"
+	}
+	san, err := existsNode(ObjectRef{Kind: "ServiceAccount", Namespace: namespace, Name: sa}, false, func() error {
+		_, err := cs.CoreV1().ServiceAccounts(namespace).Get(ctx, sa, metav1.GetOptions{})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	n.Children = append(n.Children, san)
+
+	for _, name := range sortedKeys(refs.configMaps) {
+		cn, err := existsNode(ObjectRef{Kind: "ConfigMap", Namespace: namespace, Name: name}, refs.configMaps[name], func() error {
+			_, err := cs.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		n.Children = append(n.Children, cn)
+	}
+	for _, name := range sortedKeys(refs.secrets) {
+		sn, err := existsNode(ObjectRef{Kind: "Secret", Namespace: namespace, Name: name}, refs.secrets[name], func() error {
+			_, err := cs.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		n.Children = append(n.Children, sn)
+	}
+	for _, name := range refs.claims {
+		pn, err := pvcNode(ctx, namespace, name)
+		if err != nil {
+			return err
+		}
+		n.Children = append(n.Children, pn)
+	}
+
+	svcs, err := ServicesSelectingPod(ctx, v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Labels: t.Labels}})
+	if err != nil {
+		return err
+	}
+	slices, err := GetEndpointSlices(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	for _, svc := range svcs {
+		ready, notReady := EndpointCounts(slices[svc.Name])
+		sn := &XrayNode{
+			ObjectRef: ObjectRef{Kind: "Service", Namespace: namespace, Name: svc.Name},
+			Status:    fmt.Sprintf("%d/%d endpoints ready", ready, ready+notReady),
+		}
+		if ready == 0 {
+			sn.Health = HealthWarning
+		}
+		n.Children = append(n.Children, sn)
+	}
+	return nil
+}
+
+// existsNode Build a node that is an error when the object is missing and not optional
+func existsNode(ref ObjectRef, optional bool, get func() error) (*XrayNode, error) {
+	n := &XrayNode{ObjectRef: ref, Status: "Found"}
+	err := get()
+	switch {
+	case apierrors.IsNotFound(err) && optional:
+		n.Status = "Missing (optional)"
+	case apierrors.IsNotFound(err):
+		n.Status, n.Health = "Missing", HealthError
+	case apierrors.IsForbidden(err):
+		n.Status, n.Health = "Forbidden", HealthWarning
+	case err != nil:
+		return nil, err
+	default:
+	}
+	return n, nil
+}
+
+func pvcNode(ctx context.Context, namespace string, name string) (*XrayNode, error) {
+	cs := getClientSet(ctx)
+	n := &XrayNode{ObjectRef: ObjectRef{Kind: "PersistentVolumeClaim", Namespace: namespace, Name: name}}
+	pvc, err := cs.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		n.Status, n.Health = "Missing", HealthError
+		return n, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	n.Status = string(pvc.Status.Phase)
+	if pvc.Status.Phase != v1.ClaimBound {
+		n.Health = HealthWarning
+	}
+	return n, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,84 @@
+// Package scheduling explains why a pod stays Pending. It replays the main scheduler
+// predicates locally against the nodes and pods it is given, so it never asks the
+// cluster anything itself.
+package scheduling
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+)
+
+// NodeReport tells why a pod can't go to a node, no reasons means it fits
+type NodeReport struct {
+	Node    string
+	Reasons []string
+}
+
+// Fits Tell whether the pod could be scheduled on the node
+func (r NodeReport) Fits() bool {
+	return len(r.Reasons) == 0
+}
+
+// Explanation gathers what the scheduler said and what the local checks found
+type Explanation struct {
+	// Condition is the reason and message of a false PodScheduled condition
+	Condition string
+	// Summary is the per-reason node counts of the last FailedScheduling event
+	Summary []string
+	Nodes   []NodeReport
+}
+
+// failedSchedulingRe picks the reasons out of "0/5 nodes are available: 2 Insufficient memory, 3 ...".
+var failedSchedulingRe = regexp.MustCompile(`nodes are available: (.*?)(?:\. preemption:|\.$|$)`)
+
+// Explain Tell why a pod is not scheduled, pods are the pods of every namespace and
+// namespaces give the labels namespace selectors match
+func Explain(p v1.Pod, nodes []v1.Node, pods []v1.Pod, namespaces []v1.Namespace, evs []v1.Event) Explanation {
+	var e Explanation
+	for _, c := range p.Status.Conditions {
+		if c.Type == v1.PodScheduled && c.Status == v1.ConditionFalse {
+			e.Condition = strings.TrimSpace(c.Reason + ": " + c.Message)
+		}
+	}
+	e.Summary = ParseFailedScheduling(evs)
+
+	s := newSnapshot(nodes, pods, namespaces)
+	for _, n := range nodes {
+		e.Nodes = append(e.Nodes, NodeReport{Node: n.Name, Reasons: s.reasons(p, n)})
+	}
+	// Nodes that fit first, then the ones with the fewest reasons
+	sort.SliceStable(e.Nodes, func(i, j int) bool { return len(e.Nodes[i].Reasons) < len(e.Nodes[j].Reasons) })
+	return e
+}
+
+// ParseFailedScheduling Get the reasons of the latest FailedScheduling event
+func ParseFailedScheduling(evs []v1.Event) []string {
+	var latest *v1.Event
+	for i := range evs {
+		ev := &evs[i]
+		if ev.Reason != "FailedScheduling" {
+			continue
+		}
+		if latest == nil || kubernetes.EventTimestamp(*ev).After(kubernetes.EventTimestamp(*latest)) {
+			latest = ev
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	m := failedSchedulingRe.FindStringSubmatch(latest.Message)
+	if m == nil {
+		return []string{latest.Message}
+	}
+	var reasons []string
+	for _, r := range strings.Split(m[1], ", ") {
+		if r = strings.TrimSpace(r); r != "" {
+			reasons = append(reasons, r)
+		}
+	}
+	return reasons
+}
@@ -0,0 +1,415 @@
+package scheduling
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// snapshot is the cluster state the predicates run against
+type snapshot struct {
+	nodes    map[string]v1.Node
+	pods     []v1.Pod
+	podCount map[string]int
+	requests map[string]v1.ResourceList
+	// namespaces are the labels of each namespace, for the namespace selectors of affinity terms
+	namespaces map[string]labels.Set
+}
+
+func newSnapshot(nodes []v1.Node, pods []v1.Pod, namespaces []v1.Namespace) snapshot {
+	s := snapshot{
+		nodes:      map[string]v1.Node{},
+		podCount:   map[string]int{},
+		requests:   kubernetes.NodeRequests(pods),
+		namespaces: map[string]labels.Set{},
+	}
+	for _, n := range nodes {
+		s.nodes[n.Name] = n
+	}
+	for _, ns := range namespaces {
+		s.namespaces[ns.Name] = labels.Set(ns.Labels)
+	}
+	for _, p := range pods {
+		if p.Spec.NodeName == "" || p.Status.Phase == v1.PodSucceeded || p.Status.Phase == v1.PodFailed {
+			continue
+		}
+		s.pods = append(s.pods, p)
+		s.podCount[p.Spec.NodeName]++
+	}
+	return s
+}
+
+// reasons Run every predicate of a pod against a node
+func (s snapshot) reasons(p v1.Pod, n v1.Node) []string {
+	var rs []string
+	rs = append(rs, nodeReady(n)...)
+	rs = append(rs, untoleratedTaints(p, n)...)
+	rs = append(rs, nodeSelector(p, n)...)
+	rs = append(rs, nodeAffinity(p, n)...)
+	rs = append(rs, s.resources(p, n)...)
+	rs = append(rs, s.podAffinity(p, n)...)
+	rs = append(rs, s.topologySpread(p, n)...)
+	return rs
+}
+
+func nodeReady(n v1.Node) []string {
+	for _, c := range n.Status.Conditions {
+		if c.Type == v1.NodeReady && c.Status != v1.ConditionTrue {
+			return []string{"node is not ready"}
+		}
+	}
+	return nil
+}
+
+// untoleratedTaints List the NoSchedule and NoExecute taints the pod doesn't tolerate,
+// a cordoned node counts as the unschedulable taint
+func untoleratedTaints(p v1.Pod, n v1.Node) []string {
+	taints := append([]v1.Taint(nil), n.Spec.Taints...)
+	if n.Spec.Unschedulable {
+		taints = append(taints, v1.Taint{Key: v1.TaintNodeUnschedulable, Effect: v1.TaintEffectNoSchedule})
+	}
+	var rs []string
+	for i := range taints {
+		t := &taints[i]
+		if t.Effect == v1.TaintEffectPreferNoSchedule || tolerated(p.Spec.Tolerations, t) {
+			continue
+		}
+		if t.Key == v1.TaintNodeUnschedulable {
+			rs = append(rs, "node is cordoned")
+			continue
+		}
+		taint := t.Key
+		if t.Value != "" {
+			taint = taint + "=" + t.Value
+		}
+		rs = append(rs, fmt.Sprintf("untolerated taint %s:%s", taint, t.Effect))
+	}
+	return rs
+}
+
+func tolerated(tolerations []v1.Toleration, t *v1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func nodeSelector(p v1.Pod, n v1.Node) []string {
+	var rs []string
+	for _, k := range sortedKeys(p.Spec.NodeSelector) {
+		v := p.Spec.NodeSelector[k]
+		if got, ok := n.Labels[k]; !ok || got != v {
+			rs = append(rs, fmt.Sprintf("node selector %s=%s not matched", k, v))
+		}
+	}
+	return rs
+}
+
+// nodeAffinity Check the required node affinity, the terms are ORed and their
+// expressions ANDed
+func nodeAffinity(p v1.Pod, n v1.Node) []string {
+	a := p.Spec.Affinity
+	if a == nil || a.NodeAffinity == nil || a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return nil
+	}
+	var failed []string
+	for _, term := range a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		unmatched := unmatchedTerm(term, n)
+		if len(unmatched) == 0 {
+			return nil
+		}
+		failed = append(failed, strings.Join(unmatched, " and "))
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return []string{"node affinity not matched: " + strings.Join(failed, " or ")}
+}
+
+func unmatchedTerm(term v1.NodeSelectorTerm, n v1.Node) []string {
+	var unmatched []string
+	for _, r := range term.MatchExpressions {
+		v, ok := n.Labels[r.Key]
+		if !matchRequirement(r, v, ok) {
+			unmatched = append(unmatched, describeRequirement(r))
+		}
+	}
+	for _, r := range term.MatchFields {
+		if r.Key == metav1.ObjectNameField && !matchRequirement(r, n.Name, true) {
+			unmatched = append(unmatched, describeRequirement(r))
+		}
+	}
+	return unmatched
+}
+
+func matchRequirement(r v1.NodeSelectorRequirement, v string, ok bool) bool {
+	switch r.Operator {
+	case v1.NodeSelectorOpIn:
+		return ok && contains(r.Values, v)
+	case v1.NodeSelectorOpNotIn:
+		return !ok || !contains(r.Values, v)
+	case v1.NodeSelectorOpExists:
+		return ok
+	case v1.NodeSelectorOpDoesNotExist:
+		return !ok
+	case v1.NodeSelectorOpGt, v1.NodeSelectorOpLt:
+		if !ok || len(r.Values) != 1 {
+			return false
+		}
+		got, err1 := strconv.ParseInt(v, 10, 64)
+		want, err2 := strconv.ParseInt(r.Values[0], 10, 64)
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		if r.Operator == v1.NodeSelectorOpGt {
+			return got > want
+		}
+		return got < want
+	default:
+		return false
+	}
+}
+
+func describeRequirement(r v1.NodeSelectorRequirement) string {
+	switch r.Operator {
+	case v1.NodeSelectorOpExists, v1.NodeSelectorOpDoesNotExist:
+		return fmt.Sprintf("%s %s", r.Key, r.Operator)
+	default:
+		return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ","))
+	}
+}
+
+// resources Compare the pod requests with what the other pods left free on the node
+func (s snapshot) resources(p v1.Pod, n v1.Node) []string {
+	var rs []string
+	used := s.requests[n.Name]
+	reqs := kubernetes.PodRequests(p)
+	for _, name := range sortedResourceNames(reqs) {
+		req := reqs[name]
+		if req.IsZero() {
+			continue
+		}
+		alloc, ok := n.Status.Allocatable[name]
+		if !ok {
+			rs = append(rs, fmt.Sprintf("node has no %s", name))
+			continue
+		}
+		free := alloc.DeepCopy()
+		free.Sub(used[name])
+		if req.Cmp(free) > 0 {
+			rs = append(rs, fmt.Sprintf("insufficient %s: requests %s, free %s", name, formatAmount(name, req), formatAmount(name, free)))
+		}
+	}
+	if alloc, ok := n.Status.Allocatable[v1.ResourcePods]; ok && int64(s.podCount[n.Name]) >= alloc.Value() {
+		rs = append(rs, fmt.Sprintf("too many pods: %d of %d", s.podCount[n.Name], alloc.Value()))
+	}
+	return rs
+}
+
+// podAffinity Check the required pod affinity and anti-affinity terms
+func (s snapshot) podAffinity(p v1.Pod, n v1.Node) []string {
+	a := p.Spec.Affinity
+	if a == nil {
+		return nil
+	}
+	var rs []string
+	if a.PodAffinity != nil {
+		for _, term := range a.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if len(s.matchingInDomain(p, term, n)) == 0 {
+				rs = append(rs, fmt.Sprintf("no pod matching affinity %s in %s", selectorString(term.LabelSelector), domain(term.TopologyKey, n)))
+			}
+		}
+	}
+	if a.PodAntiAffinity != nil {
+		for _, term := range a.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if m := s.matchingInDomain(p, term, n); len(m) > 0 {
+				rs = append(rs, fmt.Sprintf("pod anti-affinity with %s/%s in %s", m[0].Namespace, m[0].Name, domain(term.TopologyKey, n)))
+			}
+		}
+	}
+	return rs
+}
+
+// matchingInDomain Get the pods matching a term that run in the same topology domain as the node
+func (s snapshot) matchingInDomain(p v1.Pod, term v1.PodAffinityTerm, n v1.Node) []v1.Pod {
+	value, ok := n.Labels[term.TopologyKey]
+	if !ok {
+		return nil
+	}
+	sel, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+	if err != nil {
+		return nil
+	}
+	inNamespace, err := s.termNamespaces(p, term)
+	if err != nil {
+		return nil
+	}
+	var matching []v1.Pod
+	for _, other := range s.pods {
+		if !inNamespace(other.Namespace) || !sel.Matches(labels.Set(other.Labels)) {
+			continue
+		}
+		if on, ok := s.nodes[other.Spec.NodeName]; ok && on.Labels[term.TopologyKey] == value {
+			matching = append(matching, other)
+		}
+	}
+	return matching
+}
+
+// termNamespaces Tell which namespaces a term looks at, the ones it lists and the ones its
+// namespace selector matches, or the namespace of the pod when it has neither
+func (s snapshot) termNamespaces(p v1.Pod, term v1.PodAffinityTerm) (func(string) bool, error) {
+	if len(term.Namespaces) == 0 && term.NamespaceSelector == nil {
+		return func(ns string) bool { return ns == p.Namespace }, nil
+	}
+	nsSel := labels.Nothing()
+	if term.NamespaceSelector != nil {
+		var err error
+		if nsSel, err = metav1.LabelSelectorAsSelector(term.NamespaceSelector); err != nil {
+			return nil, err
+		}
+	}
+	return func(ns string) bool {
+		return contains(term.Namespaces, ns) || nsSel.Matches(s.namespaces[ns])
+	}, nil
+}
+
+// topologySpread Check the DoNotSchedule spread constraints, placing the pod on the node
+// must not make its domain exceed the max skew over the least loaded domain. Like the
+// scheduler's defaults, only the nodes matching the node selector and affinity of the pod
+// count, and their taints only when the constraint asks for it
+func (s snapshot) topologySpread(p v1.Pod, n v1.Node) []string {
+	var rs []string
+	for _, c := range p.Spec.TopologySpreadConstraints {
+		if c.WhenUnsatisfiable != v1.DoNotSchedule {
+			continue
+		}
+		value, ok := n.Labels[c.TopologyKey]
+		if !ok {
+			rs = append(rs, "node has no topology label "+c.TopologyKey)
+			continue
+		}
+		sel, err := metav1.LabelSelectorAsSelector(c.LabelSelector)
+		if err != nil {
+			continue
+		}
+		counts := map[string]int32{}
+		for _, other := range s.nodes {
+			if v, ok := other.Labels[c.TopologyKey]; ok && spreadsOver(p, c, other) {
+				counts[v] += 0
+			}
+		}
+		for _, other := range s.pods {
+			if other.Namespace != p.Namespace || !sel.Matches(labels.Set(other.Labels)) {
+				continue
+			}
+			if on, ok := s.nodes[other.Spec.NodeName]; ok && spreadsOver(p, c, on) {
+				if v, ok := on.Labels[c.TopologyKey]; ok {
+					counts[v]++
+				}
+			}
+		}
+		least := counts[value]
+		for _, cnt := range counts {
+			least = min(least, cnt)
+		}
+		// Too few domains leave room for more, the least loaded one counts as empty
+		if c.MinDomains != nil && int32(len(counts)) < *c.MinDomains {
+			least = 0
+		}
+		self := int32(0)
+		if sel.Matches(labels.Set(p.Labels)) {
+			self = 1
+		}
+		if skew := counts[value] + self - least; skew > c.MaxSkew {
+			rs = append(rs, fmt.Sprintf("topology spread %s=%s would skew %d > %d", c.TopologyKey, value, skew, c.MaxSkew))
+		}
+	}
+	return rs
+}
+
+// spreadsOver Tell whether a node is one a spread constraint counts, by the node affinity
+// policy (Honor by default) and the node taints policy (Ignore by default)
+func spreadsOver(p v1.Pod, c v1.TopologySpreadConstraint, n v1.Node) bool {
+	if c.NodeAffinityPolicy == nil || *c.NodeAffinityPolicy == v1.NodeInclusionPolicyHonor {
+		if len(nodeSelector(p, n)) > 0 || len(nodeAffinity(p, n)) > 0 {
+			return false
+		}
+	}
+	if c.NodeTaintsPolicy != nil && *c.NodeTaintsPolicy == v1.NodeInclusionPolicyHonor {
+		for i := range n.Spec.Taints {
+			t := &n.Spec.Taints[i]
+			if t.Effect != v1.TaintEffectPreferNoSchedule && !tolerated(p.Spec.Tolerations, t) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func domain(key string, n v1.Node) string {
+	return key + "=" + n.Labels[key]
+}
+
+func selectorString(ls *metav1.LabelSelector) string {
+	sel, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		return "<invalid selector>"
+	}
+	return sel.String()
+}
+
+// formatAmount Format cpu in cores or millicores and memory in Gi or Mi, like "1.2Gi"
+func formatAmount(name v1.ResourceName, q resource.Quantity) string {
+	switch name {
+	case v1.ResourceCPU:
+		if m := q.MilliValue(); m%1000 != 0 {
+			return fmt.Sprintf("%dm", m)
+		}
+		return strconv.FormatInt(q.Value(), 10)
+	case v1.ResourceMemory, v1.ResourceEphemeralStorage:
+		const mi, gi = 1024 * 1024, 1024 * 1024 * 1024
+		if v := q.Value(); v >= gi || v <= -gi {
+			return strings.TrimSuffix(strconv.FormatFloat(float64(v)/gi, 'f', 1, 64), ".0") + "Gi"
+		}
+		return fmt.Sprintf("%dMi", q.Value()/mi)
+	default:
+		return q.String()
+	}
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedResourceNames(rl v1.ResourceList) []v1.ResourceName {
+	names := make([]v1.ResourceName, 0, len(rl))
+	for n := range rl {
+		names = append(names, n)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
@@ -0,0 +1,149 @@
+package scheduling
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const zone = "topology.kubernetes.io/zone"
+
+func node(name string, labels map[string]string, taints ...v1.Taint) v1.Node {
+	return v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}, Spec: v1.NodeSpec{Taints: taints}}
+}
+
+func running(namespace string, name string, labels map[string]string, node string) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Spec:       v1.PodSpec{NodeName: node},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+func TestTopologySpread(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	nodes := []v1.Node{
+		node("a", map[string]string{zone: "z1", "pool": "general"}),
+		node("b", map[string]string{zone: "z2", "pool": "general"}),
+		node("c", map[string]string{zone: "z3", "pool": "gpu"}, v1.Taint{Key: "gpu", Effect: v1.TaintEffectNoSchedule}),
+	}
+	pods := []v1.Pod{
+		running("shop", "web-1", web, "a"),
+		running("shop", "web-2", web, "b"),
+		// Other namespaces don't count
+		running("staging", "web-1", web, "c"),
+	}
+	policy := func(p v1.NodeInclusionPolicy) *v1.NodeInclusionPolicy { return &p }
+	three := int32(3)
+	pending := func(c v1.TopologySpreadConstraint, selector map[string]string) v1.Pod {
+		c.TopologyKey, c.MaxSkew, c.WhenUnsatisfiable = zone, 1, v1.DoNotSchedule
+		c.LabelSelector = &metav1.LabelSelector{MatchLabels: web}
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-3", Labels: web},
+			Spec:       v1.PodSpec{NodeSelector: selector, TopologySpreadConstraints: []v1.TopologySpreadConstraint{c}},
+		}
+	}
+	tolerating := pending(v1.TopologySpreadConstraint{NodeTaintsPolicy: policy(v1.NodeInclusionPolicyHonor)}, nil)
+	tolerating.Spec.Tolerations = []v1.Toleration{{Key: "gpu", Operator: v1.TolerationOpExists}}
+
+	tests := []struct {
+		name string
+		pod  v1.Pod
+		node string
+		want []string
+	}{
+		{
+			name: "every zone counts without a node selector",
+			pod:  pending(v1.TopologySpreadConstraint{}, nil),
+			node: "a",
+			want: []string{"topology spread topology.kubernetes.io/zone=z1 would skew 2 > 1"},
+		},
+		{
+			name: "the empty zone takes it",
+			pod:  pending(v1.TopologySpreadConstraint{}, nil),
+			node: "c",
+		},
+		{
+			name: "zones the node selector rules out don't count",
+			pod:  pending(v1.TopologySpreadConstraint{}, map[string]string{"pool": "general"}),
+			node: "a",
+		},
+		{
+			name: "node affinity policy Ignore counts them again",
+			pod:  pending(v1.TopologySpreadConstraint{NodeAffinityPolicy: policy(v1.NodeInclusionPolicyIgnore)}, map[string]string{"pool": "general"}),
+			node: "a",
+			want: []string{"topology spread topology.kubernetes.io/zone=z1 would skew 2 > 1"},
+		},
+		{
+			name: "untolerated taints don't count when honored",
+			pod:  pending(v1.TopologySpreadConstraint{NodeTaintsPolicy: policy(v1.NodeInclusionPolicyHonor)}, nil),
+			node: "a",
+		},
+		{
+			name: "tolerated taints still count when honored",
+			pod:  tolerating,
+			node: "a",
+			want: []string{"topology spread topology.kubernetes.io/zone=z1 would skew 2 > 1"},
+		},
+		{
+			name: "too few domains for min domains",
+			pod:  pending(v1.TopologySpreadConstraint{MinDomains: &three}, map[string]string{"pool": "general"}),
+			node: "a",
+			want: []string{"topology spread topology.kubernetes.io/zone=z1 would skew 2 > 1"},
+		},
+	}
+	s := newSnapshot(nodes, pods, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.topologySpread(tt.pod, s.nodes[tt.node]); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("topologySpread = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodAntiAffinityNamespaces(t *testing.T) {
+	db := map[string]string{"app": "db"}
+	nodes := []v1.Node{node("a", map[string]string{zone: "z1"})}
+	pods := []v1.Pod{running("billing", "db-0", db, "a")}
+	namespaces := []v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "billing", Labels: map[string]string{"team": "payments"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"team": "storefront"}}},
+	}
+	avoiding := func(namespaces []string, selector *metav1.LabelSelector) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api"},
+			Spec: v1.PodSpec{Affinity: &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{{
+					LabelSelector:     &metav1.LabelSelector{MatchLabels: db},
+					TopologyKey:       zone,
+					Namespaces:        namespaces,
+					NamespaceSelector: selector,
+				}},
+			}}},
+		}
+	}
+	conflict := []string{"pod anti-affinity with billing/db-0 in topology.kubernetes.io/zone=z1"}
+
+	tests := []struct {
+		name string
+		pod  v1.Pod
+		want []string
+	}{
+		{"own namespace only", avoiding(nil, nil), nil},
+		{"listed namespace", avoiding([]string{"billing"}, nil), conflict},
+		{"namespace selector", avoiding(nil, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}), conflict},
+		{"namespace selector matching others", avoiding(nil, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "storefront"}}), nil},
+		{"empty namespace selector", avoiding(nil, &metav1.LabelSelector{}), conflict},
+	}
+	s := newSnapshot(nodes, pods, namespaces)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.podAffinity(tt.pod, s.nodes["a"]); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("podAffinity = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package clipboard
+
+import (
+	"github.com/aymanbagabas/go-osc52/v2"
+	"os"
+	"strings"
+)
+
+// Copy Put text on the terminal clipboard with an OSC52 sequence, works over SSH
+func Copy(text string) error {
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+	// stderr is the same terminal and keeps the sequence out of the renderer's output
+	_, err := seq.WriteTo(os.Stderr)
+	return err
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	ctx "context"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/context"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/events"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/palette"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/resources"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/storage"
+	tea "github.com/charmbracelet/bubbletea"
+	"slices"
+)
+
+// workloadKinds maps the workload views to the kind they list
+var workloadKinds = map[string]string{
+	"deployments":  "Deployment",
+	"statefulsets": "StatefulSet",
+	"daemonsets":   "DaemonSet",
+}
+
+// commandViews maps the commands that list something to their view
+var commandViews = map[string]string{
+	"pods":     "pods",
+	"deploy":   "deployments",
+	"sts":      "statefulsets",
+	"ds":       "daemonsets",
+	"svc":      "services",
+	"cm":       "configmaps",
+	"secrets":  "secrets",
+	"jobs":     "jobs",
+	"cronjobs": "jobs",
+	"ing":      "ingresses",
+	"pvc":      "storage",
+	"pv":       "storage",
+	"nodes":    "nodes",
+	"events":   "events",
+	"ctx":      "context",
+	"ns":       "namespace",
+}
+
+// run Carry out a command of the command line, a name shows that object like an event jump does
+func (m *Model) run(c palette.Command) tea.Cmd {
+	ns := m.pod.Namespace
+	switch {
+	case c.AllNamespaces:
+		ns = ""
+	case c.Namespace != "":
+		ns = c.Namespace
+	}
+
+	switch {
+	case c.Name == "quit":
+		return tea.Quit
+	case c.Name == "ctx" && c.Arg != "":
+		if !m.context.Select(c.Arg) {
+			m.notice = fmt.Sprintf("no context %q", c.Arg)
+			return nil
+		}
+		return func() tea.Msg { return context.ChangeMsg{} }
+	case c.Name == "tab":
+		if c.Arg != "" && !slices.Contains(kubernetes.ContextNames(), c.Arg) {
+			m.notice = fmt.Sprintf("no context %q", c.Arg)
+			return nil
+		}
+		return func() tea.Msg { return newTabMsg{context: c.Arg} }
+	case c.Name == "resource":
+		r, err := kubernetes.FindResource(ctx.Background(), c.Resource)
+		if err != nil {
+			m.notice = err.Error()
+			return nil
+		}
+		m.push(Resource, r.Name)
+		m.resource = resources.New(r, ns)
+		return nil
+	case c.Name == "ns" && c.Arg != "":
+		return m.openView("pods", c.Arg)
+	case c.Name == "logs":
+		p, err := kubernetes.GetPod(ctx.Background(), ns, c.Arg)
+		if err != nil {
+			m.notice = err.Error()
+			return nil
+		}
+		return m.openLogs(c.Container, *p)
+	case c.Arg != "":
+		return m.jumpTo(events.JumpMsg{Kind: c.Kind, Namespace: ns, Name: c.Arg})
+	}
+
+	cmd := m.openView(commandViews[c.Name], ns)
+	switch c.Name {
+	case "cronjobs":
+		m.job.ShowCronJobs = true
+	case "pv":
+		m.storage.Tab = storage.Volumes
+	}
+	return cmd
+}
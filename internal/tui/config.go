@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/configs"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/context"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/events"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/ingresses"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/jobs"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/keymap"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/namespace"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/nodes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/pods"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/resources"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/services"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/storage"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/workloads"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/xray"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type globalKeyMap struct {
+	Quit     key.Binding
+	Palette  key.Binding
+	Export   key.Binding
+	Jump     key.Binding
+	NewTab   key.Binding
+	CloseTab key.Binding
+	NextTab  key.Binding
+	PrevTab  key.Binding
+}
+
+var globalKeys = globalKeyMap{
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+	Palette: key.NewBinding(
+		key.WithKeys(":"),
+		key.WithHelp(":", "command"),
+	),
+	// Export is for the table views, the pod tables bind their own export key
+	Export: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "export csv/json/md"),
+	),
+	Jump: key.NewBinding(
+		key.WithKeys("alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9"),
+		key.WithHelp("alt+1-9", "jump to breadcrumb"),
+	),
+	NewTab: key.NewBinding(
+		key.WithKeys("ctrl+t"),
+		key.WithHelp("ctrl+t", "new tab"),
+	),
+	CloseTab: key.NewBinding(
+		key.WithKeys("ctrl+w"),
+		key.WithHelp("ctrl+w", "close tab"),
+	),
+	NextTab: key.NewBinding(
+		key.WithKeys("ctrl+right", "ctrl+n"),
+		key.WithHelp("ctrl+right", "next tab"),
+	),
+	PrevTab: key.NewBinding(
+		key.WithKeys("ctrl+left", "ctrl+p"),
+		key.WithHelp("ctrl+left", "previous tab"),
+	),
+}
+
+// viewKeys are the key maps of the views by their name in the configuration
+var viewKeys = map[string]struct {
+	view Views
+	keys interface{}
+}{
+	"pods":      {Pod, pods.Bindings()},
+	"context":   {Context, context.Bindings()},
+	"namespace": {Namespace, namespace.Bindings()},
+	"events":    {Event, events.Bindings()},
+	"nodes":     {Node, nodes.Bindings()},
+	"services":  {Service, services.Bindings()},
+	"configs":   {Config, configs.Bindings()},
+	"jobs":      {Job, jobs.Bindings()},
+	"ingresses": {Ingress, ingresses.Bindings()},
+	"storage":   {Storage, storage.Bindings()},
+	"xray":      {Xray, xray.Bindings()},
+	"workloads": {Workload, workloads.Bindings()},
+	"resources": {Resource, resources.Bindings()},
+}
+
+// remaps translate the keys of each view, set by LoadConfig
+var remaps = map[Views]keymap.Remap{}
+
+// mutatingKeys are the actions that change the cluster, read-only contexts disable them
+var mutatingKeys = map[Views][]*key.Binding{
+	Pod:     {&pods.Bindings().Delete, &pods.Bindings().Exec},
+	Node:    {&nodes.Bindings().Cordon, &nodes.Bindings().Uncordon, &nodes.Bindings().Drain, &nodes.Bindings().Force},
+	Config:  {&configs.Bindings().Edit},
+	Job:     {&jobs.Bindings().Trigger, &jobs.Bindings().Suspend, &jobs.Bindings().Rerun},
+	Storage: {&storage.Bindings().Resize},
+}
+
+// LoadConfig Check the user configuration and apply its key bindings, call it before NewModel
+func LoadConfig() error {
+	if config.Err != nil {
+		return config.Err
+	}
+	var errs []error
+	names := make([]string, 0, len(viewKeys))
+	for n := range viewKeys {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for view := range config.Current.Keys {
+		if _, ok := viewKeys[view]; !ok && view != "global" {
+			errs = append(errs, fmt.Errorf("keys.%s: unknown view, use one of global, %s", view, strings.Join(names, ", ")))
+		}
+	}
+	if _, err := keymap.Bind("global", &globalKeys, config.Current.Keys["global"]); err != nil {
+		errs = append(errs, err)
+	}
+	pods.Palette = globalKeys.Palette
+	for _, name := range names {
+		v := viewKeys[name]
+		r, err := keymap.Bind(name, v.keys, config.Current.Keys[name], globalKeys.Quit.Keys()...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		remaps[v.view] = r
+	}
+	errs = append(errs, bindPlugins()...)
+	if len(errs) > 0 {
+		return fmt.Errorf("%s: %w", config.Path, errors.Join(errs...))
+	}
+	return nil
+}
+
+// keyView Get the view whose keys are in use
+func (m Model) keyView() Views {
+	// Drill-downs show a pod table inside another view
+	if (m.currentView == Node && m.node.ShowPods) || (m.currentView == Service && m.service.ShowPods) {
+		return Pod
+	}
+	return m.currentView
+}
+
+// translate Map a pressed key to the key the current view handles, false when it must be ignored
+func (m Model) translate(msg tea.KeyMsg) (tea.KeyMsg, bool) {
+	r, ok := remaps[m.keyView()]
+	if !ok {
+		return msg, true
+	}
+	return r.Translate(msg)
+}
+
+// applyProfile Use the safety profile of a context, mutating actions leave the help when read-only
+func (m *Model) applyProfile(context string) {
+	m.profile = config.ProfileFor(context)
+	if _, ok := kubernetes.Snapshot(); ok {
+		// Nothing can change a snapshot, the mutating keys leave the help
+		m.profile.ReadOnly = true
+	}
+	kubernetes.SetReadOnly(m.profile.ReadOnly)
+	by := m.profile.ConfirmByName
+	m.pod.ConfirmByName = by
+	m.node.ConfirmByName, m.node.Pods.ConfirmByName = by, by
+	m.service.ConfirmByName, m.service.Pods.ConfirmByName = by, by
+	m.enableKeys()
+}
+
+// enableKeys Put the mutating actions in the help when the profile of the tab allows them, every
+// tab shares the bindings so the tab shown sets them
+func (m Model) enableKeys() {
+	for _, bs := range mutatingKeys {
+		for _, b := range bs {
+			b.SetEnabled(!m.profile.ReadOnly)
+		}
+	}
+	for v, pv := range pluginViews {
+		for i := range *pv.help {
+			b := &(*pv.help)[i]
+			if p, ok := pluginOn(v, b.Keys()[0]); ok && p.Mutating {
+				b.SetEnabled(!m.profile.ReadOnly)
+			}
+		}
+	}
+}
+
+// blocked Get the mutating action a key stands for on a read-only context
+func (m Model) blocked(msg tea.KeyMsg) (*key.Binding, bool) {
+	if !m.profile.ReadOnly {
+		return nil, false
+	}
+	for _, b := range mutatingKeys[m.keyView()] {
+		if slices.Contains(b.Keys(), msg.String()) {
+			return b, true
+		}
+	}
+	if p, ok := pluginOn(m.keyView(), msg.String()); ok && p.Mutating {
+		b := key.NewBinding(key.WithKeys(p.Key), key.WithHelp(p.Key, p.Description))
+		return &b, true
+	}
+	return nil, false
+}
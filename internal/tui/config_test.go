@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+)
+
+func TestLoadConfig(t *testing.T) {
+	plugin := func(key string) config.Plugin {
+		return config.Plugin{Scopes: []string{"pods"}, Key: key, Description: "stern", Command: "stern {{.Name}}"}
+	}
+	tests := []struct {
+		name string
+		c    config.Config
+		want string
+	}{
+		{
+			name: "unknown view",
+			c:    config.Config{Keys: map[string]map[string][]string{"pod": {"logs": {"L"}}}},
+			want: "keys.pod: unknown view, use one of global, configs, context",
+		},
+		{
+			name: "quit is reserved in the views",
+			c:    config.Config{Keys: map[string]map[string][]string{"nodes": {"drain": {"q"}}}},
+			want: `keys.nodes.drain: "q" is reserved for quit`,
+		},
+		{
+			name: "plugin on a view key",
+			c:    config.Config{Plugins: []config.Plugin{plugin("w")}},
+			want: `plugins[0].key: "w" is already bound to wide`,
+		},
+		{
+			name: "plugin on a global key",
+			c:    config.Config{Plugins: []config.Plugin{plugin(":")}},
+			want: `plugins[0].key: ":" is already bound to palette`,
+		},
+	}
+	old := config.Current
+	t.Cleanup(func() {
+		config.Current = old
+		_ = LoadConfig()
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Current = tt.c
+			if err := LoadConfig(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadConfig error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
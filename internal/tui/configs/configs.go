@@ -0,0 +1,406 @@
+package configs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/clipboard"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kind Which kind of object the view lists
+type Kind uint8
+
+const (
+	ConfigMaps Kind = iota
+	Secrets
+)
+
+// object Common view of a config map or a secret
+type object struct {
+	Name      string
+	Namespace string
+	Type      string
+	Data      map[string][]byte
+	Binary    map[string]bool
+	Age       string
+}
+
+type Model struct {
+	Kind      Kind
+	Namespace string
+	Objects   table.Model
+	Keys      table.Model
+	Help      help.Model
+	ShowKeys  bool
+	Reveal    bool
+	items     []object
+	current   object
+	dataKeys  []string
+	status    string
+}
+
+// EditedMsg is sent when the editor opened on a key exits, the patch goes to the cluster the
+// editor was opened on
+type EditedMsg struct {
+	Key  string
+	File string
+	Err  error
+	ctx  context.Context
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Help.Width = msg.Width
+		m.Objects.SetWidth(msg.Width)
+		m.Keys.SetWidth(msg.Width)
+
+	case tea.KeyMsg:
+		if m.ShowKeys {
+			return m.updateKeys(msg)
+		}
+		switch keypress := msg.String(); keypress {
+		case "enter":
+			c := m.Objects.Cursor()
+			if c >= 0 && c < len(m.items) {
+				m.current = m.items[c]
+				m.ShowKeys = true
+				m.Reveal = false
+				m.status = ""
+				m.renderKeys()
+			}
+		case "r":
+			RefreshConfigs(&m)
+		default:
+			m.Objects, cmd = m.Objects.Update(msg)
+		}
+	case EditedMsg:
+		m.applyEdit(msg)
+	}
+	return m, cmd
+}
+
+func (m Model) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	k, ok := m.selectedKey()
+	switch keypress := msg.String(); keypress {
+	case "x":
+		m.Reveal = !m.Reveal
+		m.renderKeys()
+	case "y":
+		if ok {
+			if err := clipboard.Copy(string(m.current.Data[k])); err != nil {
+				m.status = err.Error()
+			} else {
+				m.status = fmt.Sprintf("copied %s to the clipboard", k)
+			}
+		}
+	case "e":
+		if ok {
+			cmd = m.edit(k)
+		}
+	default:
+		m.Keys, cmd = m.Keys.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	if m.ShowKeys {
+		b.WriteString("\n" + titleStyle.Render(fmt.Sprintf("%s/%s %s", m.kindName(), m.current.Name, m.current.Type)) + "\n\n")
+		b.WriteString(m.Keys.View() + "\n")
+		if d := m.detail(); d != "" {
+			b.WriteString(panelStyle.Render(d) + "\n")
+		}
+	} else {
+		b.WriteString("\n" + titleStyle.Render(fmt.Sprintf("%ss (%s)", m.kindName(), m.Namespace)) + "\n\n")
+		b.WriteString(m.Objects.View() + "\n")
+	}
+	if m.status != "" {
+		b.WriteString(statusStyle.Render(m.status) + "\n")
+	}
+	b.WriteString(helpStyle.Render(m.Help.View(keys)))
+	return b.String()
+}
+
+func (m Model) kindName() string {
+	if m.Kind == Secrets {
+		return "Secret"
+	}
+	return "ConfigMap"
+}
+
+func (m Model) selectedKey() (string, bool) {
+	c := m.Keys.Cursor()
+	if c < 0 || c >= len(m.dataKeys) {
+		return "", false
+	}
+	return m.dataKeys[c], true
+}
+
+// visible Tell whether values can be shown, secrets stay masked until revealed
+func (m Model) visible() bool {
+	return m.Kind != Secrets || m.Reveal
+}
+
+// detail Render the full value of the selected key, certificates are always shown
+func (m Model) detail() string {
+	k, ok := m.selectedKey()
+	if !ok {
+		return ""
+	}
+	v := m.current.Data[k]
+	if certs, err := kubernetes.ParseCertificates(v); err == nil {
+		var cs []string
+		for _, c := range certs {
+			s := c.String()
+			if c.Expired() {
+				s = expiredStyle.Render(s)
+			}
+			cs = append(cs, s)
+		}
+		return strings.Join(cs, "\n\n")
+	}
+	if !m.visible() {
+		return k + ": " + mask
+	}
+	return k + ":\n" + printable(v)
+}
+
+// edit Open the decoded value of a key in $EDITOR
+func (m Model) edit(k string) tea.Cmd {
+	c := kubernetes.Bind(context.Background())
+	f, err := os.CreateTemp("", "k8s-manager-*")
+	if err != nil {
+		return func() tea.Msg { return EditedMsg{Key: k, Err: err, ctx: c} }
+	}
+	_, err = f.Write(m.current.Data[k])
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		return func() tea.Msg { return EditedMsg{Key: k, File: f.Name(), Err: err, ctx: c} }
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = defaultEditor
+	}
+	return tea.ExecProcess(exec.Command(editor, f.Name()), func(err error) tea.Msg {
+		return EditedMsg{Key: k, File: f.Name(), Err: err, ctx: c}
+	})
+}
+
+// applyEdit Patch the edited key back, secrets are encoded by the client
+func (m *Model) applyEdit(msg EditedMsg) {
+	if msg.File != "" {
+		defer os.Remove(msg.File)
+	}
+	if msg.Err != nil {
+		m.status = msg.Err.Error()
+		return
+	}
+	v, err := os.ReadFile(msg.File)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	old := m.current.Data[msg.Key]
+	// Editors add a final newline the original value may not have had
+	if !bytes.HasSuffix(old, []byte("\n")) {
+		v = bytes.TrimSuffix(v, []byte("\n"))
+	}
+	if bytes.Equal(old, v) {
+		m.status = "no changes"
+		return
+	}
+
+	ctx, cancelFunc := context.WithCancel(msg.ctx)
+	defer cancelFunc()
+	switch {
+	case m.Kind == Secrets:
+		err = kubernetes.PatchSecretKey(ctx, m.current.Namespace, m.current.Name, msg.Key, v)
+	case m.current.Binary[msg.Key]:
+		err = kubernetes.PatchConfigMapBinaryKey(ctx, m.current.Namespace, m.current.Name, msg.Key, v)
+	default:
+		err = kubernetes.PatchConfigMapKey(ctx, m.current.Namespace, m.current.Name, msg.Key, string(v))
+	}
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.status = fmt.Sprintf("%s updated", msg.Key)
+	RefreshConfigs(m)
+	for _, o := range m.items {
+		if o.Name == m.current.Name {
+			m.current = o
+		}
+	}
+	m.renderKeys()
+}
+
+func (m *Model) renderKeys() {
+	m.dataKeys = nil
+	for k := range m.current.Data {
+		m.dataKeys = append(m.dataKeys, k)
+	}
+	sort.Strings(m.dataKeys)
+
+	columns := []table.Column{{Title: "KEY"}, {Title: "SIZE"}, {Title: "VALUE"}}
+	var rows []table.Row
+	for _, k := range m.dataKeys {
+		v := mask
+		if m.visible() {
+			v = strings.ReplaceAll(printable(m.current.Data[k]), "\n", "\\n")
+		}
+		rows = append(rows, table.Row{k, strconv.Itoa(len(m.current.Data[k])), v})
+	}
+	setTable(&m.Keys, columns, rows)
+}
+
+// Ref Get the config map or secret under the cursor, or the one whose keys are shown
+func (m Model) Ref() (kubernetes.ObjectRef, bool) {
+	o := m.current
+	if !m.ShowKeys {
+		c := m.Objects.Cursor()
+		if c < 0 || c >= len(m.items) {
+			return kubernetes.ObjectRef{}, false
+		}
+		o = m.items[c]
+	}
+	return kubernetes.ObjectRef{Kind: m.kindName(), Namespace: o.Namespace, Name: o.Name}, true
+}
+
+// Select Move the cursor to the named config map or secret
+func (m *Model) Select(name string) {
+	for i, o := range m.items {
+		if o.Name == name {
+			m.Objects.SetCursor(i)
+			return
+		}
+	}
+}
+
+func RefreshConfigs(m *Model) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	var columns []table.Column
+	var rows []table.Row
+	m.items = nil
+	if m.Kind == Secrets {
+		scs, err := kubernetes.GetSecrets(ctx, m.Namespace)
+		if err != nil {
+			m.status = err.Error()
+		}
+		for _, s := range scs {
+			m.items = append(m.items, object{
+				Name:      s.Name,
+				Namespace: s.Namespace,
+				Type:      string(s.Type),
+				Data:      s.Data,
+				Age:       kubernetes.ColumnHelperAge(s.CreationTimestamp),
+			})
+		}
+		columns = []table.Column{{Title: "NAME"}, {Title: "TYPE"}, {Title: "DATA"}, {Title: "AGE"}}
+		for _, o := range m.items {
+			rows = append(rows, table.Row{o.Name, o.Type, strconv.Itoa(len(o.Data)), o.Age})
+		}
+	} else {
+		cms, err := kubernetes.GetConfigMaps(ctx, m.Namespace)
+		if err != nil {
+			m.status = err.Error()
+		}
+		for _, c := range cms {
+			data, binary := configMapData(c)
+			m.items = append(m.items, object{
+				Name:      c.Name,
+				Namespace: c.Namespace,
+				Data:      data,
+				Binary:    binary,
+				Age:       kubernetes.ColumnHelperAge(c.CreationTimestamp),
+			})
+		}
+		columns = []table.Column{{Title: "NAME"}, {Title: "DATA"}, {Title: "AGE"}}
+		for _, o := range m.items {
+			rows = append(rows, table.Row{o.Name, strconv.Itoa(len(o.Data)), o.Age})
+		}
+	}
+	setTable(&m.Objects, columns, rows)
+}
+
+// configMapData Merge the text and binary keys of a config map, binary tells which is which
+func configMapData(c v1.ConfigMap) (map[string][]byte, map[string]bool) {
+	d := map[string][]byte{}
+	binary := map[string]bool{}
+	for k, v := range c.Data {
+		d[k] = []byte(v)
+	}
+	for k, v := range c.BinaryData {
+		d[k] = v
+		binary[k] = true
+	}
+	return d, binary
+}
+
+// printable Get the value as text, binary values are summarized
+func printable(v []byte) string {
+	if !utf8.Valid(v) {
+		return fmt.Sprintf("<binary, %d bytes>", len(v))
+	}
+	return string(v)
+}
+
+// setTable Size the columns to their content and set the rows
+func setTable(t *table.Model, columns []table.Column, rows []table.Row) {
+	for j := range columns {
+		columns[j].Width = len(columns[j].Title)
+		for _, r := range rows {
+			columns[j].Width = min(max(columns[j].Width, len(r[j])), config.ColumnWidth(maxColumnWidth))
+		}
+		columns[j].Width += columnPadding
+	}
+	t.SetRows(nil)
+	t.SetColumns(columns)
+	t.SetRows(rows)
+}
+
+func newTable() table.Model {
+	t := table.New(
+		table.WithFocused(true),
+	)
+
+	t.SetStyles(theme.TableStyles())
+	return t
+}
+
+func New(kind Kind, namespace string) Model {
+	m := Model{
+		Kind:      kind,
+		Namespace: namespace,
+		Objects:   newTable(),
+		Keys:      newTable(),
+		Help:      help.New(),
+	}
+	RefreshConfigs(&m)
+	return m
+}
@@ -0,0 +1,24 @@
+package configs
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const maxColumnWidth = 60
+const columnPadding = 2
+
+// mask replaces secret values until they are revealed
+const mask = "••••••••"
+
+// defaultEditor is used when $EDITOR is not set
+const defaultEditor = "vi"
+
+var (
+	titleStyle   = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	statusStyle  = lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Accent)
+	expiredStyle = lipgloss.NewStyle().Foreground(theme.Error)
+	panelStyle   = lipgloss.NewStyle().MarginLeft(2).Padding(0, 1).Border(lipgloss.RoundedBorder()).BorderForeground(theme.Accent)
+	helpStyle    = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
+)
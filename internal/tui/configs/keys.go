@@ -0,0 +1,53 @@
+package configs
+
+import "github.com/charmbracelet/bubbles/key"
+
+type KeyMap struct {
+	Open    key.Binding
+	Reveal  key.Binding
+	Copy    key.Binding
+	Edit    key.Binding
+	Refresh key.Binding
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return append([]key.Binding{k.Open, k.Reveal, k.Copy, k.Edit, k.Refresh}, Plugins...)
+
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Open, k.Reveal, k.Copy, k.Edit, k.Refresh},
+	}
+}
+
+var keys = KeyMap{
+	Open: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "keys"),
+	),
+	Reveal: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "reveal"),
+	),
+	Copy: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy"),
+	),
+	Edit: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit key"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
+}
+
+// Plugins are the user commands that apply to the view, set by LoadConfig
+var Plugins []key.Binding
+
+// Bindings Get the key map so the user configuration can remap it
+func Bindings() *KeyMap {
+	return &keys
+}
@@ -0,0 +1,74 @@
+// Package confirm asks before destructive actions, with y/n or, on contexts whose
+// profile asks for it, by typing the name of the target.
+package confirm
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+)
+
+// Model is a confirmation prompt, the zero value is closed
+type Model struct {
+	question string
+	name     string
+	byName   bool
+	input    textinput.Model
+	mismatch bool
+}
+
+// New Ask a question about a target, byName asks to type the name instead of y/n
+func New(question string, name string, byName bool) (Model, tea.Cmd) {
+	m := Model{question: question, name: name, byName: byName}
+	if !m.byName {
+		return m, nil
+	}
+	m.input = textinput.New()
+	m.input.Placeholder = name
+	return m, m.input.Focus()
+}
+
+// Active Tell whether the prompt waits for an answer
+func (m Model) Active() bool {
+	return m.question != ""
+}
+
+// Typing Tell whether the prompt takes text, keys must not trigger anything else then
+func (m Model) Typing() bool {
+	return m.Active() && m.byName
+}
+
+// Update Handle a key, the prompt closes once answered and ok tells whether the user agreed
+func (m Model) Update(msg tea.KeyMsg) (_ Model, ok bool, _ tea.Cmd) {
+	if !m.byName {
+		return Model{}, msg.String() == "y", nil
+	}
+	switch msg.String() {
+	case "enter":
+		if strings.TrimSpace(m.input.Value()) == m.name {
+			return Model{}, true, nil
+		}
+		m.mismatch = true
+		return m, false, nil
+	case "esc":
+		return Model{}, false, nil
+	default:
+		var cmd tea.Cmd
+		m.mismatch = false
+		m.input, cmd = m.input.Update(msg)
+		return m, false, cmd
+	}
+}
+
+// View Render the question and, when confirming by name, the input
+func (m Model) View() string {
+	if !m.byName {
+		return questionStyle.Render(m.question + "? (y/n)")
+	}
+	s := questionStyle.Render(fmt.Sprintf("%s? Type %q to confirm, esc to cancel: ", m.question, m.name)) + m.input.View()
+	if m.mismatch {
+		s = s + "\n" + mismatchStyle.Render("the name does not match")
+	}
+	return s
+}
@@ -0,0 +1,11 @@
+package confirm
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	questionStyle = lipgloss.NewStyle().MarginLeft(2).Bold(true).Foreground(theme.Error)
+	mismatchStyle = lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Accent)
+)
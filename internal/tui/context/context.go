@@ -18,7 +18,7 @@ func New() Model {
 	name, namespace, user := kubernetes.GetCurrent()
 	return Model{
 		Contexts:        buildContextList(),
-		SelectedContext: Item{name, namespace, user},
+		SelectedContext: Item{Name: name, Namespace: namespace, User: user},
 	}
 }
 
@@ -33,8 +33,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Contexts.SetWidth(msg.Width)
 
 	case tea.KeyMsg:
-		switch keypress := msg.String(); keypress {
-		case "enter":
+		switch keypress := msg.String(); {
+		case m.Contexts.FilterState() == list.Filtering:
+			m.Contexts, cmd = m.Contexts.Update(msg)
+		case keypress == "enter":
 			m.ShowLoadingText = true
 			cmd = func() tea.Msg { return ChangeMsg{} }
 		default:
@@ -61,12 +63,36 @@ func (m Model) View() string {
 	return "\n" + m.Contexts.View()
 }
 
+// Select Move the cursor to the named context, false when there is none
+func (m *Model) Select(name string) bool {
+	m.Contexts.ResetFilter()
+	for i, it := range m.Contexts.Items() {
+		if c, ok := it.(Item); ok && c.Name == name {
+			m.Contexts.Select(i)
+			return true
+		}
+	}
+	return false
+}
+
+// Pick Make the named context the selected one without switching the kubeconfig to it, false when there is none
+func (m *Model) Pick(name string) bool {
+	if !m.Select(name) {
+		return false
+	}
+	if i, ok := m.Contexts.SelectedItem().(Item); ok {
+		m.SelectedContext = i
+	}
+	return true
+}
+
 func buildContextList() list.Model {
 	var items []list.Item
 	ctxs := kubernetes.ListContexts()
-	for _, ctx := range ctxs {
+	for _, name := range kubernetes.ContextNames() {
+		ctx := ctxs[name]
 		items = append(items, Item{
-			Name:      ctx.Cluster,
+			Name:      name,
 			Namespace: ctx.Namespace,
 			User:      ctx.AuthInfo,
 		})
@@ -74,7 +100,7 @@ func buildContextList() list.Model {
 	l := list.New(items, itemDelegate{}, defaultWidth, listHeight)
 	l.Title = "Select Context"
 	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(false)
+	l.SetFilteringEnabled(true)
 	l.Styles.Title = titleStyle
 	l.Styles.PaginationStyle = paginationStyle
 	l.Styles.HelpStyle = helpStyle
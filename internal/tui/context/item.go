@@ -40,4 +40,4 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 	}
 }
 
-func (i Item) FilterValue() string { return "" }
+func (i Item) FilterValue() string { return i.Name }
@@ -0,0 +1,27 @@
+package describe
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// labelWidth aligns the values of the describe output
+const labelWidth = 14
+
+// Findings scored at least this much are drawn as the likely or a possible cause
+const (
+	likelyCauseScore   = 80
+	possibleCauseScore = 50
+)
+
+// titleHeight is the number of lines above the viewport
+const titleHeight = 3
+
+var (
+	titleStyle   = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	sectionStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.Accent)
+
+	likelyCauseStyle   = lipgloss.NewStyle().Bold(true).Foreground(theme.Error)
+	possibleCauseStyle = lipgloss.NewStyle().Foreground(theme.Warning)
+	fitStyle           = lipgloss.NewStyle().Foreground(theme.OK)
+)
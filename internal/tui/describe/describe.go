@@ -0,0 +1,238 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/scheduling"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Model struct {
+	Title   string
+	content viewport.Model
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		m.content.Width = msg.Width
+		m.content.Height = msg.Height - titleHeight
+	}
+	m.content, cmd = m.content.Update(msg)
+	return m, cmd
+}
+
+func (m Model) View() string {
+	return "\n" + titleStyle.Render(m.Title) + "\n\n" + m.content.View()
+}
+
+func newModel(title string, content string, width int, height int) Model {
+	vp := viewport.New(width, max(height-titleHeight, 1))
+	vp.SetContent(content)
+	return Model{
+		Title:   title,
+		content: vp,
+	}
+}
+
+// NewPod Describe a pod, including the services selecting it
+func NewPod(p *v1.Pod, width int, height int) Model {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	var b strings.Builder
+	field(&b, "Name", p.Name)
+	field(&b, "Namespace", p.Namespace)
+	field(&b, "Node", p.Spec.NodeName)
+	field(&b, "Status", kubernetes.PodStatusReason(*p))
+	field(&b, "IP", p.Status.PodIP)
+	field(&b, "Age", kubernetes.ColumnHelperAge(p.CreationTimestamp))
+	field(&b, "Labels", mapLines(p.Labels)...)
+
+	section(&b, "Containers")
+	statuses := map[string]v1.ContainerStatus{}
+	for _, cs := range p.Status.ContainerStatuses {
+		statuses[cs.Name] = cs
+	}
+	for _, c := range p.Spec.Containers {
+		cs := statuses[c.Name]
+		b.WriteString("  " + c.Name + ":\n")
+		field(&b, "  Image", c.Image)
+		field(&b, "  Ready", fmt.Sprint(cs.Ready))
+		field(&b, "  Restarts", fmt.Sprint(cs.RestartCount))
+	}
+
+	section(&b, "Conditions")
+	for _, c := range p.Status.Conditions {
+		field(&b, "  "+string(c.Type), string(c.Status))
+	}
+
+	section(&b, "Services")
+	svcs, err := kubernetes.ServicesSelectingPod(ctx, *p)
+	switch {
+	case err != nil:
+		b.WriteString("  " + err.Error() + "\n")
+	case len(svcs) == 0:
+		b.WriteString("  <none>\n")
+	default:
+		for _, svc := range svcs {
+			b.WriteString(fmt.Sprintf("  %s (%s %s %s)\n", svc.Name, svc.Spec.Type, svc.Spec.ClusterIP, kubernetes.ColumnHelperPorts(svc)))
+		}
+	}
+
+	return newModel("pod/"+p.Name, b.String(), width, height)
+}
+
+// field Write a label and its values, one per line
+func field(b *strings.Builder, label string, values ...string) {
+	if len(values) == 0 {
+		values = []string{"<none>"}
+	}
+	for i, v := range values {
+		if i > 0 {
+			label = ""
+		}
+		if label != "" {
+			label = label + ":"
+		}
+		b.WriteString(fmt.Sprintf("%-*s %s\n", labelWidth, label, v))
+	}
+}
+
+func section(b *strings.Builder, title string) {
+	b.WriteString(sectionStyle.Render(title+":") + "\n")
+}
+
+func mapLines(m map[string]string) []string {
+	var ls []string
+	for k, v := range m {
+		ls = append(ls, k+"="+v)
+	}
+	sort.Strings(ls)
+	return ls
+}
+
+// NewDiagnosis Explain why a pod is failing, the most likely reason first, followed by
+// the logs of the previous run of the containers that restarted
+func NewDiagnosis(p *v1.Pod, width int, height int) Model {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	var b strings.Builder
+	evs, err := kubernetes.GetObjectEvents(ctx, p.Namespace, "Pod", p.Name)
+	if err != nil {
+		b.WriteString("events: " + err.Error() + "\n")
+	}
+	fs := kubernetes.Diagnose(*p, evs, time.Now())
+
+	section(&b, "Diagnosis")
+	if len(fs) == 0 {
+		b.WriteString("  nothing wrong found\n")
+	}
+	for i, f := range fs {
+		title := f.Title
+		if f.Container != "" {
+			title = f.Container + ": " + title
+		}
+		b.WriteString(fmt.Sprintf("%2d. %s\n", i+1, findingStyle(f.Score).Render(title)))
+		if f.Detail != "" {
+			b.WriteString("    " + f.Detail + "\n")
+		}
+	}
+
+	statuses := append(append([]v1.ContainerStatus(nil), p.Status.InitContainerStatuses...), p.Status.ContainerStatuses...)
+	for _, s := range statuses {
+		if s.RestartCount == 0 && s.LastTerminationState.Terminated == nil {
+			continue
+		}
+		section(&b, "Previous logs of "+s.Name)
+		logs, err := kubernetes.GetPreviousLogs(ctx, p.Namespace, p.Name, s.Name)
+		switch {
+		case err != nil:
+			b.WriteString("  " + err.Error() + "\n")
+		case logs == "":
+			b.WriteString("  <empty>\n")
+		default:
+			b.WriteString(logs + "\n")
+		}
+	}
+
+	return newModel("why is pod/"+p.Name+" failing", b.String(), width, height)
+}
+
+// findingStyle Color a finding by how likely it is the cause
+func findingStyle(score int) lipgloss.Style {
+	switch {
+	case score >= likelyCauseScore:
+		return likelyCauseStyle
+	case score >= possibleCauseScore:
+		return possibleCauseStyle
+	default:
+		return lipgloss.NewStyle()
+	}
+}
+
+// NewScheduling Explain why a pending pod is not scheduled, node by node
+func NewScheduling(p *v1.Pod, width int, height int) Model {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	var b strings.Builder
+	nds, err := kubernetes.GetNodes(ctx)
+	if err != nil {
+		return newModel("scheduling of pod/"+p.Name, err.Error(), width, height)
+	}
+	pds, err := kubernetes.GetPods(ctx, "")
+	if err != nil {
+		return newModel("scheduling of pod/"+p.Name, err.Error(), width, height)
+	}
+	// Without the namespaces, affinity namespace selectors match nothing
+	nss, err := kubernetes.GetNamespaces(ctx)
+	if err != nil {
+		b.WriteString("namespaces: " + err.Error() + "\n")
+	}
+	evs, err := kubernetes.GetObjectEvents(ctx, p.Namespace, "Pod", p.Name)
+	if err != nil {
+		b.WriteString("events: " + err.Error() + "\n")
+	}
+	e := scheduling.Explain(*p, nds, pds, nss, evs)
+
+	section(&b, "Scheduler")
+	if e.Condition == "" && len(e.Summary) == 0 {
+		b.WriteString("  no scheduling failure reported yet\n")
+	}
+	if e.Condition != "" {
+		b.WriteString("  " + e.Condition + "\n")
+	}
+	for _, s := range e.Summary {
+		b.WriteString("  - " + s + "\n")
+	}
+
+	section(&b, "Nodes")
+	if len(e.Nodes) == 0 {
+		b.WriteString("  <none>\n")
+	}
+	for _, n := range e.Nodes {
+		if n.Fits() {
+			b.WriteString("  " + fitStyle.Render(n.Node+": fits") + "\n")
+			continue
+		}
+		b.WriteString("  " + likelyCauseStyle.Render(n.Node) + "\n")
+		for _, r := range n.Reasons {
+			b.WriteString("    - " + r + "\n")
+		}
+	}
+
+	return newModel("scheduling of pod/"+p.Name, b.String(), width, height)
+}
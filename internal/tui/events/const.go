@@ -0,0 +1,25 @@
+package events
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"time"
+)
+
+const listHeight = 20
+const defaultWidth = 120
+
+// recentWindow is how far back a Warning counts for the header badge
+const recentWindow = 15 * time.Minute
+
+var (
+	titleStyle        = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	statusStyle       = lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Accent)
+	headerStyle       = lipgloss.NewStyle().PaddingLeft(4).Bold(true)
+	itemStyle         = lipgloss.NewStyle().PaddingLeft(4)
+	warningStyle      = lipgloss.NewStyle().PaddingLeft(4).Foreground(theme.Error)
+	selectedItemStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(theme.Accent)
+	paginationStyle   = list.DefaultStyles().PaginationStyle.PaddingLeft(4)
+	helpStyle         = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
+)
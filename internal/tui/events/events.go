@@ -0,0 +1,215 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sort"
+	"time"
+)
+
+type Model struct {
+	Namespace     string
+	AllNamespaces bool
+	TypeFilter    string
+	KindFilter    string
+	Events        list.Model
+	events        []v1.Event
+	status        string
+}
+
+type ChangeMsg watch.Event
+
+// JumpMsg asks to show the object an event is about
+type JumpMsg struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Events.SetWidth(msg.Width)
+
+	case tea.KeyMsg:
+		switch keypress := msg.String(); keypress {
+		case "enter":
+			i, ok := m.Events.SelectedItem().(item)
+			if ok {
+				o := i.event.InvolvedObject
+				cmd = func() tea.Msg { return JumpMsg{Kind: o.Kind, Namespace: o.Namespace, Name: o.Name} }
+			}
+		case "a":
+			m.AllNamespaces = !m.AllNamespaces
+			RefreshEvents(&m)
+		case "t":
+			m.TypeFilter = nextFilter(m.TypeFilter, []string{v1.EventTypeWarning, v1.EventTypeNormal})
+			m.setItems()
+		case "i":
+			m.KindFilter = nextFilter(m.KindFilter, m.kinds())
+			m.setItems()
+		default:
+			m.Events, cmd = m.Events.Update(msg)
+		}
+	case ChangeMsg:
+		m.apply(watch.Event(msg))
+	default:
+		m.Events, cmd = m.Events.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	header := headerStyle.Render(fmt.Sprintf(lineFormat, "LAST SEEN", "TYPE", "COUNT", "REASON", "OBJECT", "MESSAGE"))
+	title := titleStyle.Render(m.title())
+	if m.status != "" {
+		title = title + "\n" + statusStyle.Render(m.status)
+	}
+	return "\n" + title + "\n\n" + header + "\n" + m.Events.View()
+}
+
+// WatchNamespace Get the namespace to list and watch, empty for all namespaces
+func (m Model) WatchNamespace() string {
+	if m.AllNamespaces {
+		return ""
+	}
+	return m.Namespace
+}
+
+// RecentWarnings Count the Warning events seen in the recent window
+func (m Model) RecentWarnings() int {
+	n := 0
+	since := time.Now().Add(-recentWindow)
+	for _, e := range m.events {
+		if e.Type == v1.EventTypeWarning && kubernetes.EventTimestamp(e).After(since) {
+			n++
+		}
+	}
+	return n
+}
+
+// RefreshEvents List the events again, an error like a forbidden list shows in the status line
+func RefreshEvents(m *Model) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	evs, err := kubernetes.GetEvents(ctx, m.WatchNamespace())
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.status = ""
+	m.events = evs
+	m.setItems()
+	m.Events.ResetSelected()
+}
+
+// apply Merge a watch event into the event list
+func (m *Model) apply(e watch.Event) {
+	ev, ok := e.Object.(*v1.Event)
+	if !ok {
+		return
+	}
+	evs := make([]v1.Event, 0, len(m.events)+1)
+	for _, old := range m.events {
+		if old.UID != ev.UID {
+			evs = append(evs, old)
+		}
+	}
+	if e.Type != watch.Deleted {
+		evs = append(evs, *ev)
+	}
+	m.events = evs
+	m.setItems()
+}
+
+// setItems Filter and sort the events into the list, newest first
+func (m *Model) setItems() {
+	var evs []v1.Event
+	for _, e := range m.events {
+		if m.TypeFilter != "" && e.Type != m.TypeFilter {
+			continue
+		}
+		if m.KindFilter != "" && e.InvolvedObject.Kind != m.KindFilter {
+			continue
+		}
+		evs = append(evs, e)
+	}
+	sort.SliceStable(evs, func(i, j int) bool {
+		return kubernetes.EventTimestamp(evs[i]).After(kubernetes.EventTimestamp(evs[j]))
+	})
+
+	items := make([]list.Item, 0, len(evs))
+	for _, e := range evs {
+		items = append(items, item{event: e, showNamespace: m.AllNamespaces})
+	}
+	m.Events.SetItems(items)
+}
+
+func (m Model) title() string {
+	ns := m.Namespace
+	if m.AllNamespaces {
+		ns = "all namespaces"
+	}
+	t := fmt.Sprintf("Events (%s)", ns)
+	if m.TypeFilter != "" {
+		t = t + " type=" + m.TypeFilter
+	}
+	if m.KindFilter != "" {
+		t = t + " kind=" + m.KindFilter
+	}
+	return t
+}
+
+// kinds Get the involved object kinds present, sorted
+func (m Model) kinds() []string {
+	seen := map[string]bool{}
+	var ks []string
+	for _, e := range m.events {
+		if k := e.InvolvedObject.Kind; k != "" && !seen[k] {
+			seen[k] = true
+			ks = append(ks, k)
+		}
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+// nextFilter Cycle through no filter and each of the values
+func nextFilter(current string, values []string) string {
+	for i, v := range values {
+		if v == current && i+1 < len(values) {
+			return values[i+1]
+		}
+	}
+	if current == "" && len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func New(namespace string) Model {
+	l := list.New(nil, itemDelegate{}, defaultWidth, listHeight)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.Styles.PaginationStyle = paginationStyle
+	l.Styles.HelpStyle = helpStyle
+	l.AdditionalShortHelpKeys = keys.ShortHelp
+
+	m := Model{
+		Namespace: namespace,
+		Events:    l,
+	}
+	RefreshEvents(&m)
+	return m
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"io"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+)
+
+const lineFormat = "%-9s %-8s %-6s %-24s %-48s %s"
+
+type item struct {
+	event         v1.Event
+	showNamespace bool
+}
+
+type itemDelegate struct{}
+
+func (d itemDelegate) Height() int                             { return 1 }
+func (d itemDelegate) Spacing() int                            { return 0 }
+func (d itemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(item)
+	if !ok {
+		return
+	}
+
+	e := i.event
+	obj := strings.ToLower(e.InvolvedObject.Kind) + "/" + e.InvolvedObject.Name
+	if i.showNamespace && e.InvolvedObject.Namespace != "" {
+		obj = e.InvolvedObject.Namespace + "/" + obj
+	}
+	str := fmt.Sprintf(lineFormat,
+		kubernetes.ColumnHelperAge(metav1.NewTime(kubernetes.EventTimestamp(e))),
+		e.Type,
+		fmt.Sprintf("x%d", kubernetes.EventCount(e)),
+		e.Reason,
+		obj,
+		strings.ReplaceAll(strings.TrimSpace(e.Message), "\n", " "),
+	)
+
+	fn := itemStyle.MaxWidth(m.Width()).Render
+	if e.Type == v1.EventTypeWarning {
+		fn = warningStyle.MaxWidth(m.Width()).Render
+	}
+	if index == m.Index() {
+		fn = func(s ...string) string {
+			return selectedItemStyle.MaxWidth(m.Width()).Render("> " + strings.Join(s, " "))
+		}
+	}
+
+	_, err := fmt.Fprint(w, fn(str))
+	if err != nil {
+		return
+	}
+}
+
+func (i item) FilterValue() string { return "" }
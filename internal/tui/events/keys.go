@@ -0,0 +1,39 @@
+package events
+
+import "github.com/charmbracelet/bubbles/key"
+
+type KeyMap struct {
+	Jump          key.Binding
+	AllNamespaces key.Binding
+	Type          key.Binding
+	Kind          key.Binding
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Jump, k.AllNamespaces, k.Type, k.Kind}
+
+}
+
+var keys = KeyMap{
+	Jump: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "go to object"),
+	),
+	AllNamespaces: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "all namespaces"),
+	),
+	Type: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "filter type"),
+	),
+	Kind: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "filter kind"),
+	),
+}
+
+// Bindings Get the key map so the user configuration can remap it
+func Bindings() *KeyMap {
+	return &keys
+}
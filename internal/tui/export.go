@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/export"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/storage"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exportPrompt asks where the rows of a table view go, the pod tables have a prompt of their own
+type exportPrompt struct {
+	input textinput.Model
+	table export.Table
+	err   error
+}
+
+func newExportPrompt() exportPrompt {
+	ti := textinput.New()
+	ti.Placeholder = "csv, json or md to copy, or a file like nodes.csv"
+	return exportPrompt{input: ti}
+}
+
+// Active Tell whether the prompt is open
+func (p exportPrompt) Active() bool {
+	return p.input.Focused()
+}
+
+// exportTable Get the rows the current view shows, false for views without a table and for pod
+// tables, which export themselves
+func (m Model) exportTable() (export.Table, bool) {
+	var t table.Model
+	// Cluster resources like nodes have no namespace, the export says all
+	ns := ""
+	switch m.keyView() {
+	case Node:
+		t = m.node.Nodes
+	case Service:
+		t, ns = m.service.Services, m.service.Namespace
+	case Config:
+		t, ns = m.config.Objects, m.config.Namespace
+		if m.config.ShowKeys {
+			t = m.config.Keys
+		}
+	case Job:
+		t, ns = m.job.Jobs, m.job.Namespace
+		if m.job.ShowCronJobs {
+			t = m.job.CronJobs
+		}
+	case Ingress:
+		t, ns = m.ingress.Ingresses, m.ingress.Namespace
+		if m.ingress.ShowRoutes {
+			t = m.ingress.Routes
+		}
+	case Storage:
+		switch m.storage.Tab {
+		case storage.Volumes:
+			t = m.storage.Volumes
+		case storage.Classes:
+			t = m.storage.Classes
+		default:
+			t, ns = m.storage.Claims, m.storage.Namespace
+		}
+	case Workload:
+		t, ns = m.workload.Workloads, m.workload.Namespace
+	case Resource:
+		t, ns = m.resource.Objects, m.resource.Namespace
+	default:
+		return export.Table{}, false
+	}
+	return export.FromTable(t, m.context.SelectedContext.Name, ns), true
+}
+
+// openExport Start typing where the rows go, they are taken as shown now
+func (m *Model) openExport(t export.Table) tea.Cmd {
+	m.exporter.table = t
+	m.exporter.err = nil
+	m.exporter.input.SetValue("")
+	return m.exporter.input.Focus()
+}
+
+// updateExport Handle keys while the prompt is open, it stays open on a destination that doesn't work
+func (m *Model) updateExport(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	switch msg.String() {
+	case "enter":
+		notice, err := m.exporter.table.Save(m.exporter.input.Value())
+		if err != nil {
+			m.exporter.err = err
+			break
+		}
+		m.notice = notice
+		m.exporter.input.Blur()
+	case "esc":
+		m.exporter.input.Blur()
+	default:
+		m.exporter.err = nil
+		m.exporter.input, cmd = m.exporter.input.Update(msg)
+	}
+	return cmd
+}
+
+func (m Model) exportView() string {
+	s := titleStyle.Render(fmt.Sprintf("export %d rows to: ", len(m.exporter.table.Rows))) + m.exporter.input.View()
+	if m.exporter.err != nil {
+		s = s + "\n" + titleStyle.Render(noticeStyle.Render(m.exporter.err.Error()))
+	}
+	return s
+}
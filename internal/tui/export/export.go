@@ -0,0 +1,212 @@
+// Package export writes the rows a table view shows to CSV, JSON or a Markdown table, to a file
+// or the terminal clipboard. JSON and Markdown also tell the context, namespace and time they were
+// taken at, CSV stays plain so spreadsheets and scripts read it as is.
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/clipboard"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/x/ansi"
+	"io/fs"
+	"k8s.io/client-go/util/homedir"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Format is how the rows are written
+type Format string
+
+const (
+	CSV      Format = "csv"
+	JSON     Format = "json"
+	Markdown Format = "md"
+)
+
+// extensions map file extensions to the format written in the file
+var extensions = map[string]Format{
+	".csv":      CSV,
+	".json":     JSON,
+	".md":       Markdown,
+	".markdown": Markdown,
+}
+
+// Table is what a view shows, the rows in the order and with the filter they are shown with
+type Table struct {
+	Context string
+	// Namespace is empty when the view lists every namespace
+	Namespace string
+	Time      time.Time
+	Columns   []string
+	Rows      [][]string
+}
+
+// FromTable Get the columns and rows of a table model, untitled columns like marks are left out
+// and colors are removed from the cells
+func FromTable(t table.Model, context string, namespace string) Table {
+	e := Table{Context: context, Namespace: namespace, Time: time.Now()}
+	var idx []int
+	for i, c := range t.Columns() {
+		if c.Title == "" {
+			continue
+		}
+		idx = append(idx, i)
+		e.Columns = append(e.Columns, ansi.Strip(c.Title))
+	}
+	for _, r := range t.Rows() {
+		row := make([]string, 0, len(idx))
+		for _, i := range idx {
+			cell := ""
+			if i < len(r) {
+				cell = ansi.Strip(r[i])
+			}
+			row = append(row, cell)
+		}
+		e.Rows = append(e.Rows, row)
+	}
+	return e
+}
+
+func (t Table) namespace() string {
+	if t.Namespace == "" {
+		return "all"
+	}
+	return t.Namespace
+}
+
+// Render Write the table in a format, JSON and Markdown carry the metadata too
+func (t Table) Render(f Format) ([]byte, error) {
+	switch f {
+	case CSV:
+		return t.csv()
+	case JSON:
+		return t.json()
+	case Markdown:
+		return t.markdown(), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q, use csv, json or md", f)
+	}
+}
+
+// csv Write the header and the rows, CSV has no standard place for the metadata
+func (t Table) csv() ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if err := w.Write(t.Columns); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(t.Rows); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// json Write an object with the metadata, the columns in order and one object per row
+func (t Table) json() ([]byte, error) {
+	rows := make([]map[string]string, 0, len(t.Rows))
+	for _, r := range t.Rows {
+		row := map[string]string{}
+		for i, c := range t.Columns {
+			if i < len(r) {
+				row[c] = r[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	raw, err := json.MarshalIndent(struct {
+		Context   string              `json:"context"`
+		Namespace string              `json:"namespace"`
+		Exported  string              `json:"exported"`
+		Columns   []string            `json:"columns"`
+		Rows      []map[string]string `json:"rows"`
+	}{t.Context, t.namespace(), t.Time.Format(time.RFC3339), t.Columns, rows}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(raw, '\n'), nil
+}
+
+// markdown Write a line with the metadata above the table, ready to paste in a chat or a doc
+func (t Table) markdown() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "context: **%s** · namespace: **%s** · exported: %s\n\n", t.Context, t.namespace(), t.Time.Format(time.RFC3339))
+	line := func(cells []string) {
+		b.WriteString("|")
+		for _, c := range cells {
+			b.WriteString(" " + strings.ReplaceAll(c, "|", `\|`) + " |")
+		}
+		b.WriteString("\n")
+	}
+	line(t.Columns)
+	sep := make([]string, len(t.Columns))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	line(sep)
+	for _, r := range t.Rows {
+		line(r)
+	}
+	return b.Bytes()
+}
+
+// Destination Read where the rows go: a format alone, like md, copies them to the clipboard,
+// anything else is a file whose extension gives the format
+func Destination(s string) (Format, string, error) {
+	s = strings.TrimSpace(s)
+	for _, f := range extensions {
+		if s == string(f) {
+			return f, "", nil
+		}
+	}
+	if s == "" {
+		return "", "", fmt.Errorf("type csv, json or md to copy, or a file name")
+	}
+	f, ok := extensions[strings.ToLower(filepath.Ext(s))]
+	if !ok {
+		return "", "", fmt.Errorf("%s: the file must end in .csv, .json or .md", s)
+	}
+	if rest, found := strings.CutPrefix(s, "~/"); found {
+		s = filepath.Join(homedir.HomeDir(), rest)
+	}
+	return f, s, nil
+}
+
+// Save Write the table where the destination says, and get a notice of where it went. A file
+// that already exists is left alone
+func (t Table) Save(destination string) (string, error) {
+	f, path, err := Destination(destination)
+	if err != nil {
+		return "", err
+	}
+	raw, err := t.Render(f)
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		if err := clipboard.Copy(string(raw)); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("copied %d rows as %s to the clipboard", len(t.Rows), f), nil
+	}
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		return "", fmt.Errorf("%s already exists, pick another name", path)
+	}
+	if err != nil {
+		return "", err
+	}
+	_, err = out.Write(raw)
+	if cErr := out.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("exported %d rows to %s", len(t.Rows), path), nil
+}
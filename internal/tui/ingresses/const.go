@@ -0,0 +1,18 @@
+package ingresses
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const maxColumnWidth = 50
+const columnPadding = 2
+
+var (
+	titleStyle   = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	statusStyle  = lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Accent)
+	problemStyle = lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Error)
+	panelStyle   = lipgloss.NewStyle().MarginLeft(2).Padding(0, 1).Border(lipgloss.RoundedBorder()).BorderForeground(theme.Accent)
+	helpStyle    = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
+)
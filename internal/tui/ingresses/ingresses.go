@@ -0,0 +1,229 @@
+package ingresses
+
+import (
+	"context"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	networkingv1 "k8s.io/api/networking/v1"
+	"strings"
+)
+
+type Model struct {
+	Namespace   string
+	Ingresses   table.Model
+	Routes      table.Model
+	Help        help.Model
+	ShowRoutes  bool
+	items       []networkingv1.Ingress
+	current     networkingv1.Ingress
+	routes      []kubernetes.IngressRoute
+	tlsProblems []string
+	status      string
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Help.Width = msg.Width
+		m.Ingresses.SetWidth(msg.Width)
+		m.Routes.SetWidth(msg.Width)
+
+	case tea.KeyMsg:
+		if m.ShowRoutes {
+			switch msg.String() {
+			case "r":
+				m.resolve()
+			default:
+				m.Routes, cmd = m.Routes.Update(msg)
+			}
+			return m, cmd
+		}
+		switch keypress := msg.String(); keypress {
+		case "enter":
+			c := m.Ingresses.Cursor()
+			if c >= 0 && c < len(m.items) {
+				m.current = m.items[c]
+				m.ShowRoutes = true
+				m.resolve()
+				m.Routes.GotoTop()
+			}
+		case "r":
+			RefreshIngresses(&m)
+		default:
+			m.Ingresses, cmd = m.Ingresses.Update(msg)
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	if m.ShowRoutes {
+		b.WriteString("\n" + titleStyle.Render("Backends of ingress/"+m.current.Name) + "\n\n")
+		for _, p := range m.tlsProblems {
+			b.WriteString(problemStyle.Render("⚠ "+p) + "\n")
+		}
+		b.WriteString(m.Routes.View() + "\n")
+		if d := m.detail(); d != "" {
+			b.WriteString(panelStyle.Render(d) + "\n")
+		}
+	} else {
+		b.WriteString("\n" + titleStyle.Render(fmt.Sprintf("Ingresses (%s)", m.Namespace)) + "\n\n")
+		b.WriteString(m.Ingresses.View() + "\n")
+	}
+	if m.status != "" {
+		b.WriteString(statusStyle.Render(m.status) + "\n")
+	}
+	b.WriteString(helpStyle.Render(m.Help.View(keys)))
+	return b.String()
+}
+
+// detail Render the pods and problems of the selected route
+func (m Model) detail() string {
+	c := m.Routes.Cursor()
+	if c < 0 || c >= len(m.routes) {
+		return ""
+	}
+	r := m.routes[c]
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s%s → %s:%s\n", r.Host, r.Path, r.Service, r.Port))
+	for _, p := range r.Problems {
+		b.WriteString(problemStyle.Render("⚠ "+p) + "\n")
+	}
+	if len(r.Pods) == 0 {
+		b.WriteString("pods: <none>")
+	} else {
+		b.WriteString("pods: " + strings.Join(r.Pods, ", "))
+	}
+	if len(r.Addresses) > 0 {
+		b.WriteString("\naddresses: " + strings.Join(r.Addresses, ", "))
+	}
+	return b.String()
+}
+
+// Ref Get the ingress under the cursor, or the one whose routes are shown
+func (m Model) Ref() (kubernetes.ObjectRef, bool) {
+	ing := m.current
+	if !m.ShowRoutes {
+		c := m.Ingresses.Cursor()
+		if c < 0 || c >= len(m.items) {
+			return kubernetes.ObjectRef{}, false
+		}
+		ing = m.items[c]
+	}
+	return kubernetes.ObjectRef{Kind: "Ingress", Namespace: ing.Namespace, Name: ing.Name}, true
+}
+
+// Select Move the cursor to the named ingress
+func (m *Model) Select(name string) {
+	for i, ing := range m.items {
+		if ing.Name == name {
+			m.Ingresses.SetCursor(i)
+			return
+		}
+	}
+}
+
+// resolve Follow the rules of the current ingress down to pods
+func (m *Model) resolve() {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	routes, tls, err := kubernetes.ResolveIngress(ctx, m.current)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.status = ""
+	m.routes = routes
+	m.tlsProblems = tls
+
+	var rows []table.Row
+	for _, r := range routes {
+		problems := "ok"
+		if len(r.Problems) > 0 {
+			problems = "⚠ " + strings.Join(r.Problems, "; ")
+		}
+		rows = append(rows, table.Row{
+			r.Host,
+			r.Path,
+			r.Service,
+			r.Port,
+			fmt.Sprintf("%d/%d", r.Ready, r.Ready+r.NotReady),
+			fmt.Sprint(len(r.Pods)),
+			problems,
+		})
+	}
+	setTable(&m.Routes, []table.Column{
+		{Title: "HOST"}, {Title: "PATH"}, {Title: "SERVICE"}, {Title: "PORT"}, {Title: "READY"}, {Title: "PODS"}, {Title: "HEALTH"},
+	}, rows)
+}
+
+func RefreshIngresses(m *Model) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	ings, err := kubernetes.GetIngresses(ctx, m.Namespace)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.items = ings
+
+	var rows []table.Row
+	for _, ing := range ings {
+		rows = append(rows, table.Row{
+			ing.Name,
+			kubernetes.ColumnHelperIngressClass(ing),
+			kubernetes.ColumnHelperHosts(ing),
+			kubernetes.ColumnHelperIngressAddress(ing),
+			kubernetes.ColumnHelperTLSSecrets(ing),
+			kubernetes.ColumnHelperAge(ing.CreationTimestamp),
+		})
+	}
+	setTable(&m.Ingresses, []table.Column{
+		{Title: "NAME"}, {Title: "CLASS"}, {Title: "HOSTS"}, {Title: "ADDRESS"}, {Title: "TLS"}, {Title: "AGE"},
+	}, rows)
+}
+
+// setTable Size the columns to their content and set the rows
+func setTable(t *table.Model, columns []table.Column, rows []table.Row) {
+	for j := range columns {
+		columns[j].Width = len(columns[j].Title)
+		for _, r := range rows {
+			columns[j].Width = min(max(columns[j].Width, len(r[j])), config.ColumnWidth(maxColumnWidth))
+		}
+		columns[j].Width += columnPadding
+	}
+	t.SetRows(nil)
+	t.SetColumns(columns)
+	t.SetRows(rows)
+}
+
+func newTable() table.Model {
+	t := table.New(
+		table.WithFocused(true),
+	)
+
+	t.SetStyles(theme.TableStyles())
+	return t
+}
+
+func New(namespace string) Model {
+	m := Model{
+		Namespace: namespace,
+		Ingresses: newTable(),
+		Routes:    newTable(),
+		Help:      help.New(),
+	}
+	RefreshIngresses(&m)
+	return m
+}
@@ -0,0 +1,38 @@
+package ingresses
+
+import "github.com/charmbracelet/bubbles/key"
+
+type KeyMap struct {
+	Resolve key.Binding
+	Refresh key.Binding
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return append([]key.Binding{k.Resolve, k.Refresh}, Plugins...)
+
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Resolve, k.Refresh},
+	}
+}
+
+var keys = KeyMap{
+	Resolve: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "backends"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
+}
+
+// Plugins are the user commands that apply to the view, set by LoadConfig
+var Plugins []key.Binding
+
+// Bindings Get the key map so the user configuration can remap it
+func Bindings() *KeyMap {
+	return &keys
+}
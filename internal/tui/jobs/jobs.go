@@ -0,0 +1,362 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/xray"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Model struct {
+	Namespace    string
+	Jobs         table.Model
+	CronJobs     table.Model
+	Help         help.Model
+	ShowCronJobs bool
+	// Owner limits the jobs to the ones created by a cron job
+	Owner    string
+	jobs     []batchv1.Job
+	cronJobs []batchv1.CronJob
+	status   string
+}
+
+// LogsMsg asks to stream the logs of the pods of a job, Ctx is bound to the cluster of the job
+type LogsMsg struct {
+	Ctx       context.Context
+	Namespace string
+	Selector  string
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Help.Width = msg.Width
+		m.Jobs.SetWidth(msg.Width)
+		m.CronJobs.SetWidth(msg.Width)
+
+	case tea.KeyMsg:
+		switch keypress := msg.String(); keypress {
+		case "tab":
+			m.ShowCronJobs = !m.ShowCronJobs
+			m.status = ""
+		case "r":
+			RefreshJobs(&m)
+		case "x":
+			cmd = m.openXray()
+		default:
+			if m.ShowCronJobs {
+				cmd = m.updateCronJobs(msg)
+			} else {
+				cmd = m.updateJobs(msg)
+			}
+		}
+	}
+	return m, cmd
+}
+
+func (m *Model) updateJobs(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	job, ok := m.selectedJob()
+	switch msg.String() {
+	case "enter":
+		if ok {
+			sel, c := kubernetes.JobPodsSelector(job), kubernetes.Bind(context.Background())
+			cmd = func() tea.Msg { return LogsMsg{Ctx: c, Namespace: job.Namespace, Selector: sel} }
+		}
+	case "R":
+		if !ok {
+			break
+		}
+		if !kubernetes.JobFailed(job) {
+			m.status = fmt.Sprintf("job/%s has not failed", job.Name)
+			break
+		}
+		m.action(func(ctx context.Context) (string, error) {
+			j, err := kubernetes.RerunJob(ctx, job)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("job/%s created", j.Name), nil
+		})
+	default:
+		m.Jobs, cmd = m.Jobs.Update(msg)
+	}
+	return cmd
+}
+
+func (m *Model) updateCronJobs(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	cj, ok := m.selectedCronJob()
+	switch msg.String() {
+	case "enter":
+		if ok {
+			m.Owner = cj.Name
+			m.ShowCronJobs = false
+			m.renderJobs()
+			m.Jobs.GotoTop()
+		}
+	case "t":
+		if ok {
+			m.action(func(ctx context.Context) (string, error) {
+				j, err := kubernetes.TriggerCronJob(ctx, cj)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("job/%s created", j.Name), nil
+			})
+		}
+	case "p":
+		if ok {
+			suspend := cj.Spec.Suspend == nil || !*cj.Spec.Suspend
+			m.action(func(ctx context.Context) (string, error) {
+				if err := kubernetes.SuspendCronJob(ctx, cj.Namespace, cj.Name, suspend); err != nil {
+					return "", err
+				}
+				if suspend {
+					return fmt.Sprintf("cronjob/%s suspended", cj.Name), nil
+				}
+				return fmt.Sprintf("cronjob/%s resumed", cj.Name), nil
+			})
+		}
+	default:
+		m.CronJobs, cmd = m.CronJobs.Update(msg)
+	}
+	return cmd
+}
+
+// action Run a mutating call, report its outcome and refresh
+func (m *Model) action(fn func(ctx context.Context) (string, error)) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	s, err := fn(ctx)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.status = s
+	RefreshJobs(m)
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	jobsTab, cronTab := activeStyle, tabStyle
+	if m.ShowCronJobs {
+		jobsTab, cronTab = tabStyle, activeStyle
+	}
+	jobsTitle := "Jobs"
+	if m.Owner != "" {
+		jobsTitle = "Jobs of cronjob/" + m.Owner
+	}
+	b.WriteString("\n" + titleStyle.Render(jobsTab.Render(jobsTitle)+cronTab.Render("CronJobs")+fmt.Sprintf(" (%s)", m.Namespace)) + "\n\n")
+	if m.ShowCronJobs {
+		b.WriteString(m.CronJobs.View() + "\n")
+	} else {
+		b.WriteString(m.Jobs.View() + "\n")
+	}
+	if m.status != "" {
+		b.WriteString(statusStyle.Render(m.status) + "\n")
+	}
+	b.WriteString(helpStyle.Render(m.Help.View(keys)))
+	return b.String()
+}
+
+func (m Model) visibleJobs() []batchv1.Job {
+	if m.Owner == "" {
+		return m.jobs
+	}
+	var js []batchv1.Job
+	for _, j := range m.jobs {
+		if c := metav1.GetControllerOf(&j); c != nil && c.Kind == "CronJob" && c.Name == m.Owner {
+			js = append(js, j)
+		}
+	}
+	return js
+}
+
+func (m Model) selectedJob() (batchv1.Job, bool) {
+	js := m.visibleJobs()
+	c := m.Jobs.Cursor()
+	if c < 0 || c >= len(js) {
+		return batchv1.Job{}, false
+	}
+	return js[c], true
+}
+
+func (m Model) selectedCronJob() (batchv1.CronJob, bool) {
+	c := m.CronJobs.Cursor()
+	if c < 0 || c >= len(m.cronJobs) {
+		return batchv1.CronJob{}, false
+	}
+	return m.cronJobs[c], true
+}
+
+// Ref Get the job or cron job under the cursor
+func (m Model) Ref() (kubernetes.ObjectRef, bool) {
+	if m.ShowCronJobs {
+		cj, ok := m.selectedCronJob()
+		return kubernetes.ObjectRef{Kind: "CronJob", Namespace: cj.Namespace, Name: cj.Name}, ok
+	}
+	j, ok := m.selectedJob()
+	return kubernetes.ObjectRef{Kind: "Job", Namespace: j.Namespace, Name: j.Name}, ok
+}
+
+// openXray Ask for the tree of the selected job or cron job
+func (m Model) openXray() tea.Cmd {
+	msg := xray.OpenMsg{Kind: "Job", Namespace: m.Namespace}
+	if m.ShowCronJobs {
+		cj, ok := m.selectedCronJob()
+		if !ok {
+			return nil
+		}
+		msg.Kind, msg.Namespace, msg.Name = "CronJob", cj.Namespace, cj.Name
+	} else {
+		j, ok := m.selectedJob()
+		if !ok {
+			return nil
+		}
+		msg.Namespace, msg.Name = j.Namespace, j.Name
+	}
+	return func() tea.Msg { return msg }
+}
+
+// Select Move the cursor to the named job or cron job
+func (m *Model) Select(kind string, name string) {
+	if kind == "CronJob" {
+		m.ShowCronJobs = true
+		for i, cj := range m.cronJobs {
+			if cj.Name == name {
+				m.CronJobs.SetCursor(i)
+			}
+		}
+		return
+	}
+	m.ShowCronJobs = false
+	m.Owner = ""
+	m.renderJobs()
+	for i, j := range m.jobs {
+		if j.Name == name {
+			m.Jobs.SetCursor(i)
+		}
+	}
+}
+
+// Crumb Get the breadcrumb of the cron job the jobs are limited to, empty when not limited
+func (m Model) Crumb() string {
+	if m.Owner != "" {
+		return "cronjob/" + m.Owner
+	}
+	return ""
+}
+
+// ClearOwner Show the jobs of every owner again
+func (m *Model) ClearOwner() {
+	m.Owner = ""
+	m.renderJobs()
+}
+
+func RefreshJobs(m *Model) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	js, err := kubernetes.GetJobs(ctx, m.Namespace)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	cjs, err := kubernetes.GetCronJobs(ctx, m.Namespace)
+	if err != nil {
+		m.status = "cron jobs: " + err.Error()
+	}
+	// Newest first, that's the one you usually want
+	sort.SliceStable(js, func(i, j int) bool {
+		return js[j].CreationTimestamp.Before(&js[i].CreationTimestamp)
+	})
+	m.jobs = js
+	m.cronJobs = cjs
+	m.renderJobs()
+
+	var rows []table.Row
+	for _, cj := range cjs {
+		suspend := cj.Spec.Suspend != nil && *cj.Spec.Suspend
+		rows = append(rows, table.Row{
+			cj.Name,
+			cj.Spec.Schedule,
+			strconv.FormatBool(suspend),
+			strconv.Itoa(len(cj.Status.Active)),
+			kubernetes.ColumnHelperLastSchedule(cj),
+			kubernetes.ColumnHelperAge(cj.CreationTimestamp),
+		})
+	}
+	setTable(&m.CronJobs, []table.Column{
+		{Title: "NAME"}, {Title: "SCHEDULE"}, {Title: "SUSPEND"}, {Title: "ACTIVE"}, {Title: "LAST SCHEDULE"}, {Title: "AGE"},
+	}, rows)
+}
+
+func (m *Model) renderJobs() {
+	var rows []table.Row
+	for _, j := range m.visibleJobs() {
+		owner := "<none>"
+		if c := metav1.GetControllerOf(&j); c != nil {
+			owner = strings.ToLower(c.Kind) + "/" + c.Name
+		}
+		rows = append(rows, table.Row{
+			j.Name,
+			kubernetes.ColumnHelperJobStatus(j),
+			kubernetes.ColumnHelperCompletions(j),
+			kubernetes.ColumnHelperDuration(j),
+			kubernetes.ColumnHelperAge(j.CreationTimestamp),
+			owner,
+		})
+	}
+	setTable(&m.Jobs, []table.Column{
+		{Title: "NAME"}, {Title: "STATUS"}, {Title: "COMPLETIONS"}, {Title: "DURATION"}, {Title: "AGE"}, {Title: "OWNER"},
+	}, rows)
+}
+
+// setTable Size the columns to their content and set the rows
+func setTable(t *table.Model, columns []table.Column, rows []table.Row) {
+	for j := range columns {
+		columns[j].Width = len(columns[j].Title)
+		for _, r := range rows {
+			columns[j].Width = min(max(columns[j].Width, len(r[j])), config.ColumnWidth(maxColumnWidth))
+		}
+		columns[j].Width += columnPadding
+	}
+	t.SetRows(nil)
+	t.SetColumns(columns)
+	t.SetRows(rows)
+}
+
+func newTable() table.Model {
+	t := table.New(
+		table.WithFocused(true),
+	)
+
+	t.SetStyles(theme.TableStyles())
+	return t
+}
+
+func New(namespace string) Model {
+	m := Model{
+		Namespace: namespace,
+		Jobs:      newTable(),
+		CronJobs:  newTable(),
+		Help:      help.New(),
+	}
+	RefreshJobs(&m)
+	return m
+}
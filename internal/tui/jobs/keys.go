@@ -0,0 +1,63 @@
+package jobs
+
+import "github.com/charmbracelet/bubbles/key"
+
+type KeyMap struct {
+	Switch  key.Binding
+	Open    key.Binding
+	Trigger key.Binding
+	Suspend key.Binding
+	Rerun   key.Binding
+	Xray    key.Binding
+	Refresh key.Binding
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return append([]key.Binding{k.Switch, k.Open, k.Trigger, k.Suspend, k.Rerun, k.Xray, k.Refresh}, Plugins...)
+
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Switch, k.Open, k.Trigger, k.Suspend, k.Rerun, k.Xray, k.Refresh},
+	}
+}
+
+var keys = KeyMap{
+	Switch: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "jobs/cronjobs"),
+	),
+	Open: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "logs/jobs"),
+	),
+	Trigger: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "trigger"),
+	),
+	Suspend: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "suspend/resume"),
+	),
+	Rerun: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "rerun failed"),
+	),
+	Xray: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "xray"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
+}
+
+// Plugins are the user commands that apply to the view, set by LoadConfig
+var Plugins []key.Binding
+
+// Bindings Get the key map so the user configuration can remap it
+func Bindings() *KeyMap {
+	return &keys
+}
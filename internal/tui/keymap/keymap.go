@@ -0,0 +1,174 @@
+// Package keymap applies the key bindings of the user configuration. The views keep
+// handling their default keys, a Remap translates what the user pressed back to them.
+package keymap
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Remap translates the keys of a view
+type Remap struct {
+	// to maps a key the user bound to the default key of the action
+	to map[string]string
+	// off holds default keys that were moved to other keys
+	off map[string]bool
+}
+
+// Translate Get the key the view handles for a pressed key, false when the key was
+// remapped away and must be ignored
+func (r Remap) Translate(msg tea.KeyMsg) (tea.KeyMsg, bool) {
+	k := msg.String()
+	if def, ok := r.to[k]; ok {
+		if def == k {
+			return msg, true
+		}
+		return Parse(def), true
+	}
+	if r.off[k] {
+		return msg, false
+	}
+	return msg, true
+}
+
+// keyTypes maps the names of the special keys to their type
+var keyTypes = func() map[string]tea.KeyType {
+	types := map[string]tea.KeyType{}
+	for t := tea.KeyType(-128); t < 128; t++ {
+		if name := t.String(); name != "" {
+			if _, ok := types[name]; !ok {
+				types[name] = t
+			}
+		}
+	}
+	return types
+}()
+
+// Valid Tell whether a key name is one the terminal can send
+func Valid(k string) bool {
+	k = strings.TrimPrefix(k, "alt+")
+	if _, ok := keyTypes[k]; ok {
+		return true
+	}
+	r, size := utf8.DecodeRuneInString(k)
+	return size == len(k) && r != utf8.RuneError && unicode.IsPrint(r)
+}
+
+// Parse Build the key message a key name stands for
+func Parse(k string) tea.KeyMsg {
+	alt := false
+	if k != "alt+" && strings.HasPrefix(k, "alt+") {
+		alt = true
+		k = strings.TrimPrefix(k, "alt+")
+	}
+	if t, ok := keyTypes[k]; ok {
+		return tea.KeyMsg{Type: t, Alt: alt}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k), Alt: alt}
+}
+
+func actionName(field string) string {
+	return strings.ToLower(field[:1]) + field[1:]
+}
+
+// Keys Get the action each key of a key map stands for, disabled actions included
+func Keys(km interface{}) map[string]string {
+	keys := map[string]string{}
+	v := reflect.ValueOf(km).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if b, ok := v.Field(i).Addr().Interface().(*key.Binding); ok {
+			for _, k := range b.Keys() {
+				keys[k] = actionName(v.Type().Field(i).Name)
+			}
+		}
+	}
+	return keys
+}
+
+// Bind Rebind the actions of the key map of a view. When an action gets as many keys as it
+// had they replace its keys one for one, like the sort keys, otherwise every new key stands
+// for the first default key. Reserved keys, like quit, can't be bound.
+func Bind(view string, km interface{}, bindings map[string][]string, reserved ...string) (Remap, error) {
+	r := Remap{to: map[string]string{}, off: map[string]bool{}}
+	fields := map[string]*key.Binding{}
+	v := reflect.ValueOf(km).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if b, ok := v.Field(i).Addr().Interface().(*key.Binding); ok {
+			fields[actionName(v.Type().Field(i).Name)] = b
+		}
+	}
+
+	var errs []error
+	fail := func(action string, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("keys.%s.%s: %s", view, action, fmt.Sprintf(format, args...)))
+	}
+	actions := make([]string, 0, len(bindings))
+	for a := range bindings {
+		actions = append(actions, a)
+	}
+	sort.Strings(actions)
+	// bound tells which action a key ends up on, to report keys bound twice
+	bound := map[string]string{}
+	for a, b := range fields {
+		if _, remapped := bindings[a]; !remapped {
+			for _, k := range b.Keys() {
+				bound[k] = a
+			}
+		}
+	}
+
+	for _, a := range actions {
+		b, ok := fields[a]
+		if !ok {
+			known := make([]string, 0, len(fields))
+			for f := range fields {
+				known = append(known, f)
+			}
+			sort.Strings(known)
+			fail(a, "unknown action, use one of %s", strings.Join(known, ", "))
+			continue
+		}
+		keys := bindings[a]
+		defaults := b.Keys()
+		if len(defaults) == 0 {
+			fail(a, "can't be remapped")
+			continue
+		}
+		for i, k := range keys {
+			if !Valid(k) {
+				fail(a, "%q is not a key", k)
+				continue
+			}
+			if slices.Contains(reserved, k) {
+				fail(a, "%q is reserved for quit", k)
+				continue
+			}
+			if other, ok := bound[k]; ok && other != a {
+				fail(a, "%q is already bound to %s", k, other)
+				continue
+			}
+			bound[k] = a
+			def := defaults[0]
+			if len(keys) == len(defaults) {
+				def = defaults[i]
+			}
+			r.to[k] = def
+		}
+		for _, k := range defaults {
+			r.off[k] = true
+		}
+		help := b.Help()
+		b.SetKeys(keys...)
+		b.SetHelp(strings.Join(keys, "/"), help.Desc)
+	}
+	return r, errors.Join(errs...)
+}
@@ -0,0 +1,124 @@
+package keymap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type testKeys struct {
+	Up   key.Binding
+	Sort key.Binding
+	Logs key.Binding
+	Off  key.Binding
+}
+
+func newTestKeys() *testKeys {
+	return &testKeys{
+		Up:   key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+		Sort: key.NewBinding(key.WithKeys("1", "2"), key.WithHelp("1/2", "sort")),
+		Logs: key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "logs")),
+		Off:  key.NewBinding(key.WithDisabled()),
+	}
+}
+
+func TestBind(t *testing.T) {
+	// want maps a pressed key to the key the view gets, "" when it is ignored, help gets a rebound
+	// action and the help key it must show
+	tests := []struct {
+		name     string
+		bindings map[string][]string
+		want     map[string]string
+		help     func(km *testKeys) (key.Binding, string)
+	}{
+		{
+			name:     "as many keys replace them one for one",
+			bindings: map[string][]string{"sort": {"a", "b"}},
+			want:     map[string]string{"a": "1", "b": "2", "1": "", "2": "", "l": "l"},
+			help:     func(km *testKeys) (key.Binding, string) { return km.Sort, "a/b" },
+		},
+		{
+			name:     "other counts stand for the first key",
+			bindings: map[string][]string{"up": {"w", "ctrl+p", "alt+w"}},
+			want:     map[string]string{"w": "up", "ctrl+p": "up", "alt+w": "up", "up": "", "k": ""},
+			help:     func(km *testKeys) (key.Binding, string) { return km.Up, "w/ctrl+p/alt+w" },
+		},
+		{
+			name:     "keys can be swapped",
+			bindings: map[string][]string{"up": {"l"}, "logs": {"k"}},
+			want:     map[string]string{"l": "up", "k": "l", "up": ""},
+		},
+		{
+			name:     "no bindings",
+			bindings: nil,
+			want:     map[string]string{"k": "k", "1": "1", "l": "l", "x": "x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			km := newTestKeys()
+			r, err := Bind("pods", km, tt.bindings, "q")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for pressed, want := range tt.want {
+				got, ok := r.Translate(Parse(pressed))
+				if want == "" {
+					if ok {
+						t.Errorf("Translate(%q) = %q, want it ignored", pressed, got.String())
+					}
+					continue
+				}
+				if !ok || got.String() != want {
+					t.Errorf("Translate(%q) = %q, %t, want %q", pressed, got.String(), ok, want)
+				}
+			}
+			if tt.help != nil {
+				if b, want := tt.help(km); b.Help().Key != want {
+					t.Errorf("help key = %q, want %q", b.Help().Key, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		bindings map[string][]string
+		want     string
+	}{
+		{"unknown action", map[string][]string{"tail": {"t"}}, "keys.pods.tail: unknown action, use one of logs, off, sort, up"},
+		{"not a key", map[string][]string{"logs": {"ctrl+wat"}}, `keys.pods.logs: "ctrl+wat" is not a key`},
+		{"two characters", map[string][]string{"logs": {"lo"}}, `keys.pods.logs: "lo" is not a key`},
+		{"reserved", map[string][]string{"logs": {"q"}}, `keys.pods.logs: "q" is reserved for quit`},
+		{"bound twice", map[string][]string{"logs": {"k"}}, `keys.pods.logs: "k" is already bound to up`},
+		{"bound twice by the user", map[string][]string{"logs": {"x"}, "up": {"x"}}, `keys.pods.up: "x" is already bound to logs`},
+		{"no default key", map[string][]string{"off": {"o"}}, "keys.pods.off: can't be remapped"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Bind("pods", newTestKeys(), tt.bindings, "q")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Bind error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, k := range []string{"a", " ", "enter", "ctrl+a", "alt+x", "alt+enter", "shift+tab", "?"} {
+		if !Valid(k) {
+			t.Errorf("Valid(%q) = false", k)
+		}
+		if got := Parse(k).String(); got != k {
+			t.Errorf("Parse(%q) = %q", k, got)
+		}
+	}
+	for _, k := range []string{"", "ab", "ctrl+wat", "alt+"} {
+		if Valid(k) {
+			t.Errorf("Valid(%q) = true", k)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package layout
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The share of the first pane stays between minRatio and maxRatio percent, resizing moves it by ratioStep
+const (
+	minRatio  = 20
+	maxRatio  = 80
+	ratioStep = 10
+)
+
+var (
+	focusedStyle = lipgloss.NewStyle().Foreground(theme.Accent)
+	dividerStyle = lipgloss.NewStyle().Faint(true)
+)
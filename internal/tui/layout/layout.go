@@ -0,0 +1,99 @@
+// Package layout shares the screen between two panes, one above the other or side by side.
+// The divider points at the pane that has the focus.
+package layout
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+)
+
+// Direction Which way the panes are laid out
+type Direction uint8
+
+const (
+	// Vertical stacks the panes, the first one on top
+	Vertical Direction = iota
+	// Horizontal puts the panes side by side, the first one on the left
+	Horizontal
+)
+
+// Split is two panes sharing an area
+type Split struct {
+	Direction Direction
+	// Ratio is the share of the first pane, in percent
+	Ratio int
+	// Focus is the pane that takes the keys, 0 or 1
+	Focus int
+}
+
+// New Get a split with both panes the same size, the first one focused
+func New(d Direction) Split {
+	return Split{Direction: d, Ratio: 50}
+}
+
+// Sizes Get the width and height of both panes, the divider takes a line or a column
+func (s Split) Sizes(width int, height int) (w1 int, h1 int, w2 int, h2 int) {
+	if s.Direction == Horizontal {
+		w1 = max((width-1)*s.Ratio/100, 1)
+		return w1, height, max(width-1-w1, 1), height
+	}
+	h1 = max((height-1)*s.Ratio/100, 1)
+	return width, h1, width, max(height-1-h1, 1)
+}
+
+// Resize Grow the focused pane, a negative step shrinks it
+func (s *Split) Resize(steps int) {
+	if s.Focus == 1 {
+		steps = -steps
+	}
+	s.Ratio = min(max(s.Ratio+steps*ratioStep, minRatio), maxRatio)
+}
+
+// Next Move the focus to the other pane
+func (s *Split) Next() {
+	s.Focus = 1 - s.Focus
+}
+
+// Rotate Switch between panes stacked and side by side
+func (s *Split) Rotate() {
+	if s.Direction == Vertical {
+		s.Direction = Horizontal
+	} else {
+		s.Direction = Vertical
+	}
+}
+
+// Render Lay both panes out in the area, each one is cut or padded to its size
+func (s Split) Render(first string, second string, width int, height int) string {
+	w1, h1, w2, h2 := s.Sizes(width, height)
+	a, b := fit(first, w1, h1), fit(second, w2, h2)
+	if s.Direction == Horizontal {
+		return lipgloss.JoinHorizontal(lipgloss.Top, a, s.divider(height), b)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, a, s.divider(width), b)
+}
+
+// divider Draw the line between the panes, with an arrow towards the focused one
+func (s Split) divider(length int) string {
+	line, arrows := "─", [2]string{"▲", "▼"}
+	if s.Direction == Horizontal {
+		line, arrows = "│", [2]string{"◀", "▶"}
+	}
+	parts := make([]string, 0, length)
+	for i := 0; i < length; i++ {
+		parts = append(parts, dividerStyle.Render(line))
+	}
+	if length > 2 {
+		parts[1] = focusedStyle.Render(arrows[s.Focus])
+	}
+	if s.Direction == Horizontal {
+		return strings.Join(parts, "\n")
+	}
+	return strings.Join(parts, "")
+}
+
+// fit Cut a pane to its size and pad it so the other pane lines up
+func fit(content string, width int, height int) string {
+	cut := lipgloss.NewStyle().MaxWidth(width).MaxHeight(height).Render(content)
+	return lipgloss.Place(width, height, lipgloss.Left, lipgloss.Top, cut)
+}
@@ -2,21 +2,45 @@ package logs
 
 import (
 	"context"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"strings"
 )
 
+var errorStyle = lipgloss.NewStyle().Foreground(theme.Error)
+
 type Model struct {
 	logs    viewport.Model
 	buffer  []string
 	Ctx     context.Context
 	cancel  context.CancelFunc
 	LogChan chan string
+	// preview streams into a pane next to another view, its lines come as PreviewLogMsg
+	preview bool
 }
 
 type NewLogMsg string
 
+// PreviewLogMsg is a line of a log preview, tagged with its stream so lines of a previous pod are dropped
+type PreviewLogMsg struct {
+	ctx  context.Context
+	Line string
+}
+
+// ErrMsg tells why a stream of the log view ended, tagged with the view so errors of one left are dropped
+type ErrMsg struct {
+	ctx context.Context
+	Err error
+}
+
+// Failed Get the message telling the log view a stream of it ended with an error
+func (m Model) Failed(err error) ErrMsg {
+	return ErrMsg{ctx: m.Ctx, Err: err}
+}
+
 func New(c context.Context, width int, height int) Model {
 	ctx, cancel := context.WithCancel(c)
 	logChan := make(chan string)
@@ -31,11 +55,23 @@ func New(c context.Context, width int, height int) Model {
 	return m
 }
 
+// NewPreview Get a log model for a pane next to another view
+func NewPreview(c context.Context, width int, height int) Model {
+	m := New(c, width, height)
+	m.preview = true
+	return m
+}
+
 func WatchLogs(m Model) tea.Cmd {
 	return func() tea.Msg {
 		select {
 		case log := <-m.LogChan:
+			if m.preview {
+				return PreviewLogMsg{ctx: m.Ctx, Line: log}
+			}
 			return NewLogMsg(log)
+		case <-m.Ctx.Done():
+			return nil
 		}
 	}
 }
@@ -58,18 +94,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.logs.Width = msg.Width
 		m.logs.Height = msg.Height
 		m.logs.SetContent(strings.Join(m.buffer, ""))
-	case NewLogMsg:
-		gob := false
-		if m.logs.AtBottom() {
-			gob = true
+	case PreviewLogMsg:
+		if msg.ctx != m.Ctx {
+			return m, nil
 		}
-		m.buffer = append(m.buffer, string(msg))
-		m.logs.SetContent(strings.Join(m.buffer, ""))
+		m.appendLine(msg.Line)
 		cmds = append(cmds, WatchLogs(m))
-		if gob {
-			m.logs.GotoBottom()
+	case NewLogMsg:
+		m.appendLine(string(msg))
+		cmds = append(cmds, WatchLogs(m))
+	case ErrMsg:
+		if msg.ctx == m.Ctx {
+			m.appendLine(errorStyle.Render("error: "+msg.Err.Error()) + "\n")
 		}
-
 	}
 
 	m.logs, cmd = m.logs.Update(msg)
@@ -78,6 +115,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// appendLine Add a line, following the end of the logs unless scrolled up
+func (m *Model) appendLine(line string) {
+	gob := false
+	if m.logs.AtBottom() {
+		gob = true
+	}
+	m.buffer = append(m.buffer, line)
+	// Drop the oldest lines past the configured buffer size
+	if n := config.Current.Logs.Buffer; n > 0 && len(m.buffer) > n {
+		m.buffer = m.buffer[len(m.buffer)-n:]
+	}
+	m.logs.SetContent(strings.Join(m.buffer, ""))
+	if gob {
+		m.logs.GotoBottom()
+	}
+}
+
 func (m Model) View() string {
 	return m.logs.View()
 }
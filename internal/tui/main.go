@@ -3,13 +3,32 @@ package tui
 import (
 	ctx "context"
 	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
 	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/configs"
 	"github.com/OliveiraNt/k8s-manager/internal/tui/context"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/describe"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/events"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/ingresses"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/jobs"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/layout"
 	"github.com/OliveiraNt/k8s-manager/internal/tui/logs"
 	"github.com/OliveiraNt/k8s-manager/internal/tui/namespace"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/nodes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/palette"
 	"github.com/OliveiraNt/k8s-manager/internal/tui/pods"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/resources"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/services"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/storage"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/workloads"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/xray"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
 	"strings"
 )
@@ -21,71 +40,435 @@ const (
 	Namespace
 	Pod
 	Log
+	Event
+	Node
+	Service
+	Describe
+	Config
+	Job
+	Ingress
+	Storage
+	Xray
+	Workload
+	Resource
 )
 
-var titleStyle = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+var (
+	titleStyle   = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	warningBadge = lipgloss.NewStyle().Foreground(theme.BadgeForeground).Background(theme.Error).Padding(0, 1)
+	profileBadge = lipgloss.NewStyle().Foreground(theme.BadgeForeground).Bold(true).Padding(0, 1)
+	noticeStyle  = lipgloss.NewStyle().Foreground(theme.Warning)
+	crumbStyle   = lipgloss.NewStyle().Foreground(theme.Accent)
+)
 
 type Model struct {
-	context     context.Model
-	namespace   namespace.Model
-	pod         pods.Model
-	watch       watch.Interface
-	log         logs.Model
+	context    context.Model
+	namespace  namespace.Model
+	pod        pods.Model
+	watch      watch.Interface
+	log        logs.Model
+	event      events.Model
+	eventWatch watch.Interface
+	node       nodes.Model
+	service    services.Model
+	describe   describe.Model
+	config     configs.Model
+	job        jobs.Model
+	ingress    ingresses.Model
+	storage    storage.Model
+	xray       xray.Model
+	workload   workloads.Model
+	resource   resources.Model
+	palette    palette.Model
+	exporter   exportPrompt
+	pane       logPane
+	// id tells the messages of this tab from the ones of other tabs
+	id int
+	// background tabs keep their watches but skip what can wait, stale and metricsDue are what waits
+	background  bool
+	stale       bool
+	metricsDue  []pods.MetricsTickMsg
 	currentView Views
+	stack       []frame
+	crumb       string
+	profile     config.Profile
+	notice      string
 	width       int
 	height      int
 }
 
-func NewModel() Model {
-	_, ns, _ := kubernetes.GetCurrent()
+// newModel Get a tab on a kube context, the current one of the kubeconfig when empty
+func newModel(kubeContext string) Model {
+	c := context.New()
+	if kubeContext != "" && kubeContext != c.SelectedContext.Name {
+		c.Pick(kubeContext)
+	}
+	kubernetes.Use(c.SelectedContext.Name)
+	ns := c.SelectedContext.Namespace
+	if config.Current.Defaults.Namespace != "" {
+		ns = config.Current.Defaults.Namespace
+	}
 	if ns == "" {
 		ns = "default"
 	}
-	c, cancelFunc := ctx.WithCancel(ctx.Background())
-	defer cancelFunc()
-	w, err := kubernetes.WatchPods(c, ns)
-	if err != nil {
-		panic(err)
-	}
 	m := Model{
 		currentView: Pod,
-		context:     context.New(),
+		context:     c,
 		pod:         pods.New(ns),
 		namespace:   namespace.New(ns),
-		watch:       w,
+		watch:       watchPods(ns, metav1.ListOptions{}),
+		event:       events.New(ns),
+		eventWatch:  watchEvents(ns),
+		palette:     palette.New(),
+		exporter:    newExportPrompt(),
+		pane:        logPane{split: layout.New(layout.Vertical)},
 	}
+	m.applyProfile(m.context.SelectedContext.Name)
+	m.openView(config.Current.Defaults.View, ns)
 	return m
 }
 
-func watchPodEvents(sub <-chan watch.Event) tea.Cmd {
+// watchEndedMsg tells a watch ended, stopped on purpose or given up by the server
+type watchEndedMsg struct {
+	watch watch.Interface
+}
+
+func watchPodEvents(w watch.Interface) tea.Cmd {
 	return func() tea.Msg {
-		return pods.ChangeMsg(<-sub)
+		e, ok := <-w.ResultChan()
+		if !ok {
+			return watchEndedMsg{watch: w}
+		}
+		return pods.ChangeMsg(e)
 	}
 }
 
-func watchPods(ns string) watch.Interface {
-	c, cancelFunc := ctx.WithCancel(ctx.Background())
-	defer cancelFunc()
-	w, err := kubernetes.WatchPods(c, ns)
+// watchPods Watch pods until the watch is stopped, a cancelled context would end it right away
+func watchPods(ns string, opts metav1.ListOptions) watch.Interface {
+	w, err := kubernetes.WatchPods(ctx.Background(), ns, opts)
 	if err != nil {
-		panic(err)
+		// The pods still list on refresh, they just don't follow changes
+		return watch.NewFake()
 	}
 	return w
 }
 
+func watchEventEvents(w watch.Interface) tea.Cmd {
+	return func() tea.Msg {
+		e, ok := <-w.ResultChan()
+		if !ok {
+			return watchEndedMsg{watch: w}
+		}
+		return events.ChangeMsg(e)
+	}
+}
+
+// tickMetrics Hand a metrics tick to every pod table, the one whose polling sent it takes it
+func (m *Model) tickMetrics(msg pods.MetricsTickMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	var cmd tea.Cmd
+	var podModel, nodeModel, serviceModel tea.Model
+	podModel, cmd = m.pod.Update(msg)
+	if pod, ok := podModel.(pods.Model); ok {
+		m.pod = pod
+	}
+	cmds = append(cmds, cmd)
+	nodeModel, cmd = m.node.Update(msg)
+	if n, ok := nodeModel.(nodes.Model); ok {
+		m.node = n
+	}
+	cmds = append(cmds, cmd)
+	serviceModel, cmd = m.service.Update(msg)
+	if svc, ok := serviceModel.(services.Model); ok {
+		m.service = svc
+	}
+	return tea.Batch(append(cmds, cmd)...)
+}
+
+// rewatch Watch again when the server gave up a watch in use, what changed meanwhile is listed again
+func (m *Model) rewatch(w watch.Interface) tea.Cmd {
+	switch w {
+	case m.watch:
+		m.watch = watchPods(m.pod.Namespace, m.pod.ListOptions())
+		switch {
+		case m.background:
+			m.stale = true
+		case m.currentView == Pod:
+			pods.RefreshPods(&m.pod, false)
+		}
+		return watchPodEvents(m.watch)
+	case m.eventWatch:
+		m.eventWatch = watchEvents(m.event.WatchNamespace())
+		events.RefreshEvents(&m.event)
+		return watchEventEvents(m.eventWatch)
+	default:
+		// Stopped on purpose, a newer watch took its place
+		return nil
+	}
+}
+
+// watchEvents Watch events until the watch is stopped. Events are extras, one the user can't
+// watch gets a watch that never sends and the events view tells why.
+func watchEvents(ns string) watch.Interface {
+	w, err := kubernetes.WatchEvents(ctx.Background(), ns)
+	if err != nil {
+		return watch.NewFake()
+	}
+	return w
+}
+
+// restartEventWatch Watch events again after the watched namespace changed
+func (m *Model) restartEventWatch() tea.Cmd {
+	m.eventWatch.Stop()
+	m.eventWatch = watchEvents(m.event.WatchNamespace())
+	return watchEventEvents(m.eventWatch)
+}
+
+// switchNamespace Show the pods and events of another namespace
+func (m *Model) switchNamespace(ns string) tea.Cmd {
+	m.pod.Namespace = ns
+	pods.RefreshPods(&m.pod, true)
+	return m.watchNamespace()
+}
+
+// watchNamespace Watch the pods and events the pod view shows, after its namespace or selectors changed
+func (m *Model) watchNamespace() tea.Cmd {
+	ns := m.pod.Namespace
+	m.watch.Stop()
+	m.namespace.SelectedNamespace = ns
+	m.watch = watchPods(ns, m.pod.ListOptions())
+	cmd := watchPodEvents(m.watch)
+	m.event.Namespace = ns
+	if !m.event.AllNamespaces {
+		events.RefreshEvents(&m.event)
+		cmd = tea.Batch(cmd, m.restartEventWatch())
+	}
+	return cmd
+}
+
+// jumpTo Show the object an event is about
+func (m *Model) jumpTo(msg events.JumpMsg) tea.Cmd {
+	var cmd tea.Cmd
+	crumb := crumbOf(kubernetes.ObjectRef{Kind: msg.Kind, Namespace: msg.Namespace, Name: msg.Name})
+	switch msg.Kind {
+	case "Pod":
+		m.push(Pod, crumb)
+		if msg.Namespace != m.pod.Namespace {
+			cmd = m.switchNamespace(msg.Namespace)
+		}
+		m.pod.Select(msg.Name)
+	case "Node":
+		m.showNodes(crumb)
+		m.node.Select(msg.Name)
+	case "Service":
+		m.push(Service, crumb)
+		if msg.Namespace != m.pod.Namespace {
+			cmd = m.switchNamespace(msg.Namespace)
+		}
+		m.service = services.New(m.pod.Namespace)
+		m.service.ConfirmByName = m.profile.ConfirmByName
+		m.service.Select(msg.Name)
+	case "Job", "CronJob":
+		m.push(Job, crumb)
+		if msg.Namespace != m.pod.Namespace {
+			cmd = m.switchNamespace(msg.Namespace)
+		}
+		m.job = jobs.New(m.pod.Namespace)
+		m.job.Select(msg.Kind, msg.Name)
+	case "Ingress":
+		m.push(Ingress, crumb)
+		if msg.Namespace != m.pod.Namespace {
+			cmd = m.switchNamespace(msg.Namespace)
+		}
+		m.ingress = ingresses.New(m.pod.Namespace)
+		m.ingress.Select(msg.Name)
+	case "ConfigMap", "Secret":
+		m.push(Config, crumb)
+		if msg.Namespace != m.pod.Namespace {
+			cmd = m.switchNamespace(msg.Namespace)
+		}
+		kind := configs.ConfigMaps
+		if msg.Kind == "Secret" {
+			kind = configs.Secrets
+		}
+		m.config = configs.New(kind, m.pod.Namespace)
+		m.config.Select(msg.Name)
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet":
+		m.openXray(xray.New(msg.Kind, msg.Namespace, msg.Name, m.width, m.height))
+	case "PersistentVolumeClaim", "PersistentVolume":
+		m.push(Storage, crumb)
+		if msg.Namespace != "" && msg.Namespace != m.pod.Namespace {
+			cmd = m.switchNamespace(msg.Namespace)
+		}
+		m.storage = storage.New(m.pod.Namespace)
+		m.storage.Select(msg.Kind, msg.Name)
+	default:
+	}
+	return cmd
+}
+
+// openXray Show a resource tree, esc goes back to the current view
+func (m *Model) openXray(x xray.Model) {
+	crumb := "xray"
+	if root, ok := x.Root(); ok {
+		crumb = crumbOf(root)
+	}
+	m.push(Xray, crumb)
+	m.xray = x
+}
+
+// openView Show a view by its name in the configuration, in a namespace
+func (m *Model) openView(name string, ns string) tea.Cmd {
+	var cmd tea.Cmd
+	// show Push a namespaced view, following the namespace it is opened in
+	show := func(v Views, crumb string) {
+		m.push(v, crumb)
+		if ns != m.pod.Namespace {
+			cmd = m.switchNamespace(ns)
+		}
+	}
+	switch name {
+	case "pods":
+		m.reset()
+		if ns != m.pod.Namespace {
+			cmd = m.switchNamespace(ns)
+		}
+	case "context":
+		m.push(Context, "contexts")
+	case "namespace":
+		m.push(Namespace, "namespaces")
+	case "events":
+		show(Event, name)
+	case "nodes":
+		m.showNodes(name)
+	case "services":
+		show(Service, name)
+		m.service = services.New(ns)
+		m.service.ConfirmByName = m.profile.ConfirmByName
+	case "configmaps":
+		show(Config, name)
+		m.config = configs.New(configs.ConfigMaps, ns)
+	case "secrets":
+		show(Config, name)
+		m.config = configs.New(configs.Secrets, ns)
+	case "jobs":
+		show(Job, name)
+		m.job = jobs.New(ns)
+	case "ingresses":
+		show(Ingress, name)
+		m.ingress = ingresses.New(ns)
+	case "storage":
+		show(Storage, name)
+		m.storage = storage.New(ns)
+	case "deployments", "statefulsets", "daemonsets":
+		show(Workload, name)
+		m.workload = workloads.New(workloadKinds[name], ns)
+	default:
+	}
+	return cmd
+}
+
+// showNodes Switch to the nodes view, listing the nodes the first time
+func (m *Model) showNodes(crumb string) {
+	m.push(Node, crumb)
+	if m.node.Loaded() {
+		nodes.RefreshNodes(&m.node)
+	} else {
+		m.node = nodes.New()
+		m.node.ConfirmByName = m.profile.ConfirmByName
+	}
+	m.node.ShowPods = false
+}
+
+// openLogs Stream the logs of a container of pods into the log view, prefixed by pod when there
+// are several, an empty container is the default one
+func (m *Model) openLogs(container string, pds ...v1.Pod) tea.Cmd {
+	crumb := "logs"
+	// Name the pod unless the breadcrumb before already does
+	if pod := crumbOf(kubernetes.ObjectRef{Kind: "Pod", Name: pds[0].Name}); len(pds) == 1 && pod != m.crumb {
+		crumb = pod + " logs"
+	}
+	m.push(Log, crumb)
+	m.log = logs.New(kubernetes.Bind(ctx.Background()), m.width, m.height)
+	lm, c, out := m.log, m.log.Ctx, m.log.LogChan
+	// A stream that can't start, like a wrong container, says why in the log view
+	if len(pds) == 1 {
+		stream := func() tea.Msg {
+			err := kubernetes.GetPodLogs(c, pds[0].Namespace, pds[0].Name, container, config.Current.Logs.Tail, out)
+			if err != nil && c.Err() == nil {
+				return lm.Failed(err)
+			}
+			return nil
+		}
+		return tea.Batch(stream, logs.WatchLogs(m.log))
+	}
+	cmds := []tea.Cmd{logs.WatchLogs(m.log)}
+	for _, p := range pds {
+		cmds = append(cmds, func() tea.Msg {
+			in := make(chan string)
+			errc := make(chan error, 1)
+			go func() {
+				errc <- kubernetes.GetPodLogs(c, p.Namespace, p.Name, container, config.Current.Logs.Tail, in)
+				close(in)
+			}()
+			for line := range in {
+				select {
+				case out <- p.Name + " | " + line:
+				case <-c.Done():
+					return nil
+				}
+			}
+			if err := <-errc; err != nil && c.Err() == nil {
+				return lm.Failed(fmt.Errorf("%s: %w", p.Name, err))
+			}
+			return nil
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
 func (m Model) Init() tea.Cmd {
-	return watchPodEvents(m.watch.ResultChan())
+	return tea.Batch(watchPodEvents(m.watch), watchEventEvents(m.eventWatch))
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 
-	// Handle quit keys regardless of the message type
+	// Handle quit keys regardless of the message type, printable ones type text in prompts
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
-		if keypress := keyMsg.String(); keypress == "q" || keypress == "ctrl+c" {
+		if key.Matches(keyMsg, globalKeys.Quit) && (keyMsg.Type != tea.KeyRunes || !m.typing()) {
 			return m, tea.Quit
 		}
+		if m.palette.Active() {
+			m.palette, cmd = m.palette.Update(keyMsg)
+			return m, cmd
+		}
+		if m.exporter.Active() {
+			return m, m.updateExport(keyMsg)
+		}
+		if !m.typing() {
+			m.notice = ""
+			if b, ok := m.blocked(keyMsg); ok {
+				m.notice = fmt.Sprintf("read-only context: %s is disabled", b.Help().Desc)
+				return m, nil
+			}
+			if c, ok := m.jump(keyMsg); ok {
+				return m, c
+			}
+			if key.Matches(keyMsg, globalKeys.Palette) {
+				return m, m.palette.Open(m.pod.Namespace)
+			}
+			if t, ok := m.exportTable(); ok && key.Matches(keyMsg, globalKeys.Export) {
+				return m, m.openExport(t)
+			}
+			if c, ok := m.plugin(keyMsg); ok {
+				return m, c
+			}
+			if msg, ok = m.translate(keyMsg); !ok {
+				return m, nil
+			}
+		}
 	}
 
 	switch msg := msg.(type) {
@@ -93,6 +476,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		cmd = handleOtherMsgTypes(m, cmd, msg)
+		m.resizePanes()
 	case tea.KeyMsg:
 		switch m.currentView {
 		case Pod:
@@ -103,15 +487,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.updateNamespaceView(msg, &cmd)
 		case Log:
 			m.updateLogView(msg, &cmd)
+		case Event:
+			m.updateEventView(msg, &cmd)
+		case Node:
+			m.updateNodeView(msg, &cmd)
+		case Service:
+			m.updateServiceView(msg, &cmd)
+		case Describe:
+			m.updateDescribeView(msg, &cmd)
+		case Config:
+			m.updateConfigView(msg, &cmd)
+		case Job:
+			m.updateJobView(msg, &cmd)
+		case Ingress:
+			m.updateIngressView(msg, &cmd)
+		case Storage:
+			m.updateStorageView(msg, &cmd)
+		case Xray:
+			m.updateXrayView(msg, &cmd)
+		case Workload:
+			m.updateWorkloadView(msg, &cmd)
+		case Resource:
+			m.updateResourceView(msg, &cmd)
 		default:
 		}
 	case context.ChangeMsg:
 		m.watch.Stop()
+		m.stopPreview()
 		var ctxModel tea.Model
 		ctxModel, cmd = m.context.Update(msg)
 		if ctxM, ok := ctxModel.(context.Model); ok {
 			m.context = ctxM
 			m.context.ShowLoadingText = false
+			kubernetes.Use(m.context.SelectedContext.Name)
+			m.applyProfile(m.context.SelectedContext.Name)
+			m.reset()
 			ns := m.context.SelectedContext.Namespace
 			if ns == "" {
 				ns = "default"
@@ -119,13 +529,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.namespace = namespace.New(ns)
 			m.pod.Namespace = ns
 			pods.RefreshPods(&m.pod, true)
-			m.watch = watchPods(ns)
-			cmd = tea.Batch(cmd, watchPodEvents(m.watch.ResultChan()))
-			m.currentView = Pod
+			m.watch = watchPods(ns, m.pod.ListOptions())
+			cmd = tea.Batch(cmd, watchPodEvents(m.watch))
+			m.eventWatch.Stop()
+			m.event = events.New(ns)
+			m.eventWatch = watchEvents(ns)
+			cmd = tea.Batch(cmd, watchEventEvents(m.eventWatch))
 		}
 	case pods.ChangeMsg:
-		switch m.currentView {
-		case Pod:
+		switch {
+		case m.background:
+			// A hidden tab lists its pods once shown again, however many changes came meanwhile
+			m.stale = true
+		case m.currentView == Pod:
 			var podModel tea.Model
 			podModel, cmd = m.pod.Update(msg)
 			if pod, ok := podModel.(pods.Model); ok {
@@ -133,7 +549,82 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		default:
 		}
-		cmd = tea.Batch(cmd, watchPodEvents(m.watch.ResultChan()))
+		cmd = tea.Batch(cmd, watchPodEvents(m.watch))
+	case events.ChangeMsg:
+		var eventModel tea.Model
+		eventModel, cmd = m.event.Update(msg)
+		if ev, ok := eventModel.(events.Model); ok {
+			m.event = ev
+		}
+		cmd = tea.Batch(cmd, watchEventEvents(m.eventWatch))
+	case watchEndedMsg:
+		cmd = m.rewatch(msg.watch)
+	case pods.SelectorMsg:
+		m.watch.Stop()
+		m.watch = watchPods(m.pod.Namespace, m.pod.ListOptions())
+		cmd = watchPodEvents(m.watch)
+	case pods.MetricsTickMsg:
+		if m.background {
+			// A hidden tab doesn't poll, the tick waits until it is shown
+			m.metricsDue = append(m.metricsDue, msg)
+			break
+		}
+		cmd = m.tickMetrics(msg)
+	case pods.BulkDoneMsg:
+		var podModel tea.Model
+		podModel, cmd = m.pod.Update(msg)
+		if pod, ok := podModel.(pods.Model); ok {
+			m.pod = pod
+		}
+	case events.JumpMsg:
+		cmd = m.jumpTo(msg)
+	case palette.CommandMsg:
+		cmd = m.run(msg.Command)
+	case pluginDoneMsg:
+		m.notice = pluginNotice(msg)
+	case xray.OpenMsg:
+		m.openXray(xray.New(msg.Kind, msg.Namespace, msg.Name, m.width, m.height))
+	case configs.EditedMsg:
+		var cfgModel tea.Model
+		cfgModel, cmd = m.config.Update(msg)
+		if cfg, ok := cfgModel.(configs.Model); ok {
+			m.config = cfg
+		}
+	case jobs.LogsMsg:
+		pds, err := kubernetes.GetPodsWithSelector(msg.Ctx, msg.Namespace, msg.Selector)
+		if err != nil {
+			m.notice = err.Error()
+			break
+		}
+		if len(pds) > 0 {
+			cmd = m.openLogs("", pds...)
+		}
+	case nodes.DrainMsg, nodes.DrainDoneMsg:
+		var nodeModel tea.Model
+		nodeModel, cmd = m.node.Update(msg)
+		if n, ok := nodeModel.(nodes.Model); ok {
+			m.node = n
+		}
+	case previewMsg:
+		if msg.seq == m.pane.seq && m.pane.on {
+			cmd = m.startPreview()
+		}
+	case previewErrMsg:
+		if msg.ref == m.pane.streaming {
+			m.pane.err = msg.err
+		}
+	case logs.PreviewLogMsg:
+		var logModel tea.Model
+		logModel, cmd = m.pane.logs.Update(msg)
+		if logM, ok := logModel.(logs.Model); ok {
+			m.pane.logs = logM
+		}
+	case logs.ErrMsg:
+		var logModel tea.Model
+		logModel, cmd = m.log.Update(msg)
+		if logM, ok := logModel.(logs.Model); ok {
+			m.log = logM
+		}
 	case logs.NewLogMsg:
 		switch m.currentView {
 		case Log:
@@ -149,9 +640,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmd = handleOtherMsgTypes(m, cmd, msg)
 	}
 
+	// The preview follows the cursor however it moved, by a key or by pods coming and going
+	if m.pane.on && m.currentView == Pod {
+		cmd = tea.Batch(cmd, m.followCursor())
+	}
 	return m, cmd
 }
 
+// typing Tell whether the current view has a text input taking the keys
+func (m Model) typing() bool {
+	if m.palette.Active() || m.exporter.Active() {
+		return true
+	}
+	switch m.currentView {
+	case Pod:
+		return m.pod.Typing()
+	case Context:
+		return m.context.Contexts.FilterState() == list.Filtering
+	case Namespace:
+		return m.namespace.Namespaces.FilterState() == list.Filtering
+	case Node:
+		return m.node.Typing()
+	case Service:
+		return m.service.Typing()
+	case Storage:
+		return m.storage.Resizing
+	default:
+		return false
+	}
+}
+
 func handleOtherMsgTypes(m Model, cmd tea.Cmd, msg tea.Msg) tea.Cmd {
 	switch m.currentView {
 	case Pod:
@@ -178,6 +696,66 @@ func handleOtherMsgTypes(m Model, cmd tea.Cmd, msg tea.Msg) tea.Cmd {
 		if ns, ok := nsModel.(namespace.Model); ok {
 			m.namespace = ns
 		}
+	case Event:
+		var eventModel tea.Model
+		eventModel, cmd = m.event.Update(msg)
+		if ev, ok := eventModel.(events.Model); ok {
+			m.event = ev
+		}
+	case Node:
+		var nodeModel tea.Model
+		nodeModel, cmd = m.node.Update(msg)
+		if n, ok := nodeModel.(nodes.Model); ok {
+			m.node = n
+		}
+	case Service:
+		var svcModel tea.Model
+		svcModel, cmd = m.service.Update(msg)
+		if svc, ok := svcModel.(services.Model); ok {
+			m.service = svc
+		}
+	case Describe:
+		var descModel tea.Model
+		descModel, cmd = m.describe.Update(msg)
+		if d, ok := descModel.(describe.Model); ok {
+			m.describe = d
+		}
+	case Config:
+		var cfgModel tea.Model
+		cfgModel, cmd = m.config.Update(msg)
+		if cfg, ok := cfgModel.(configs.Model); ok {
+			m.config = cfg
+		}
+	case Job:
+		var jobModel tea.Model
+		jobModel, cmd = m.job.Update(msg)
+		if j, ok := jobModel.(jobs.Model); ok {
+			m.job = j
+		}
+	case Ingress:
+		var ingModel tea.Model
+		ingModel, cmd = m.ingress.Update(msg)
+		if ing, ok := ingModel.(ingresses.Model); ok {
+			m.ingress = ing
+		}
+	case Storage:
+		var stModel tea.Model
+		stModel, cmd = m.storage.Update(msg)
+		if st, ok := stModel.(storage.Model); ok {
+			m.storage = st
+		}
+	case Workload:
+		var wlModel tea.Model
+		wlModel, cmd = m.workload.Update(msg)
+		if wl, ok := wlModel.(workloads.Model); ok {
+			m.workload = wl
+		}
+	case Resource:
+		var resModel tea.Model
+		resModel, cmd = m.resource.Update(msg)
+		if res, ok := resModel.(resources.Model); ok {
+			m.resource = res
+		}
 	default:
 	}
 	return cmd
@@ -185,22 +763,77 @@ func handleOtherMsgTypes(m Model, cmd tea.Cmd, msg tea.Msg) tea.Cmd {
 
 func (m *Model) updatePodView(msg tea.Msg, cmd *tea.Cmd) {
 	keypress := msg.(tea.KeyMsg).String()
+	if m.pod.Typing() {
+		keypress = ""
+	}
+	switch {
+	case keypress == "v":
+		*cmd = m.toggleSplit()
+		return
+	case !m.pane.on || keypress == "":
+	case keypress == "V":
+		m.pane.split.Rotate()
+		m.resizePanes()
+		return
+	case keypress == "tab":
+		m.pane.split.Next()
+		return
+	case keypress == "+" || keypress == "-":
+		if keypress == "+" {
+			m.pane.split.Resize(1)
+		} else {
+			m.pane.split.Resize(-1)
+		}
+		m.resizePanes()
+		return
+	case m.pane.split.Focus == 1:
+		m.updatePreview(msg.(tea.KeyMsg), cmd)
+		return
+	}
+	// A pod view drilled into goes back once esc has nothing left to clear
+	if keypress == "esc" && len(m.stack) > 0 && !m.pod.CanClear() {
+		*cmd = m.back()
+		return
+	}
 	switch keypress {
-	case "c":
-		m.currentView = Context
-	case "n":
-		m.currentView = Namespace
+	case "e":
+		m.openView("events", m.pod.Namespace)
+	case "N":
+		m.openView("nodes", m.pod.Namespace)
+	case "s":
+		m.openView("services", m.pod.Namespace)
+	case "m":
+		m.openView("configmaps", m.pod.Namespace)
+	case "S":
+		m.openView("secrets", m.pod.Namespace)
+	case "d":
+		if p, ok := m.pod.SelectedPod(); ok {
+			m.push(Describe, "describe "+crumbOf(kubernetes.ObjectRef{Kind: "Pod", Name: p.Name}))
+			m.describe = describe.NewPod(p, m.width, m.height)
+		}
+	case "J":
+		m.openView("jobs", m.pod.Namespace)
+	case "I":
+		m.openView("ingresses", m.pod.Namespace)
+	case "P":
+		m.openView("storage", m.pod.Namespace)
+	case "W":
+		if p, ok := m.pod.SelectedPod(); ok {
+			m.push(Describe, "why "+crumbOf(kubernetes.ObjectRef{Kind: "Pod", Name: p.Name}))
+			if p.Status.Phase == v1.PodPending && p.Spec.NodeName == "" {
+				m.describe = describe.NewScheduling(p, m.width, m.height)
+			} else {
+				m.describe = describe.NewDiagnosis(p, m.width, m.height)
+			}
+		}
+	case "o":
+		if p, ok := m.pod.SelectedPod(); ok {
+			m.openXray(xray.NewForPod(p, m.width, m.height))
+		}
 	case "enter":
-		m.log = logs.New(ctx.Background(), m.width, m.height)
-		go func() {
-			_ = kubernetes.GetPodLogs(
-				m.log.Ctx,
-				m.namespace.SelectedNamespace,
-				m.pod.Pods.SelectedRow()[0],
-				m.log.LogChan)
-		}()
-		*cmd = logs.WatchLogs(m.log)
-		m.currentView = Log
+		if pds := m.pod.Marked(); len(pds) > 0 {
+			*cmd = m.openLogs("", pds...)
+		}
 	default:
 		var podModel tea.Model
 		var c tea.Cmd
@@ -216,8 +849,7 @@ func (m *Model) updateLogView(msg tea.Msg, cmd *tea.Cmd) {
 	keypress := msg.(tea.KeyMsg).String()
 	switch keypress {
 	case "esc":
-		m.log.Stop()
-		m.currentView = Pod
+		*cmd = m.back()
 	default:
 		var logModel tea.Model
 		var c tea.Cmd
@@ -233,10 +865,10 @@ func (m *Model) updateContextView(msg tea.Msg, cmd *tea.Cmd) {
 	keypress := msg.(tea.KeyMsg).String()
 	var ctxModel tea.Model
 	var c tea.Cmd
-	switch keypress {
-	case "esc":
-		m.currentView = Pod
-	case "enter":
+	switch {
+	case keypress == "esc" && m.context.Contexts.FilterState() == list.Unfiltered:
+		*cmd = m.back()
+	case keypress == "enter":
 		ctxModel, c = m.context.Update(msg)
 		*cmd = c
 		if ctxM, ok := ctxModel.(context.Model); ok {
@@ -253,22 +885,18 @@ func (m *Model) updateContextView(msg tea.Msg, cmd *tea.Cmd) {
 
 func (m *Model) updateNamespaceView(msg tea.Msg, cmd *tea.Cmd) {
 	keypress := msg.(tea.KeyMsg).String()
-	switch keypress {
-	case "esc":
-		m.currentView = Pod
-	case "enter":
-		m.watch.Stop()
+	switch {
+	case keypress == "esc" && m.namespace.Namespaces.FilterState() == list.Unfiltered:
+		*cmd = m.back()
+	case keypress == "enter" && m.namespace.Namespaces.FilterState() != list.Filtering:
 		var nsModel tea.Model
 		var c tea.Cmd
 		nsModel, c = m.namespace.Update(msg)
 		*cmd = c
 		if ns, ok := nsModel.(namespace.Model); ok {
 			m.namespace = ns
-			m.pod.Namespace = m.namespace.SelectedNamespace
-			pods.RefreshPods(&m.pod, true)
-			m.watch = watchPods(m.namespace.SelectedNamespace)
-			*cmd = tea.Batch(*cmd, watchPodEvents(m.watch.ResultChan()))
-			m.currentView = Pod
+			m.reset()
+			*cmd = tea.Batch(*cmd, m.switchNamespace(m.namespace.SelectedNamespace))
 		}
 
 	default:
@@ -282,13 +910,263 @@ func (m *Model) updateNamespaceView(msg tea.Msg, cmd *tea.Cmd) {
 	}
 }
 
+func (m *Model) updateEventView(msg tea.Msg, cmd *tea.Cmd) {
+	keypress := msg.(tea.KeyMsg).String()
+	switch keypress {
+	case "esc":
+		*cmd = m.back()
+	default:
+		watched := m.event.WatchNamespace()
+		var eventModel tea.Model
+		var c tea.Cmd
+		eventModel, c = m.event.Update(msg)
+		*cmd = c
+		if ev, ok := eventModel.(events.Model); ok {
+			m.event = ev
+		}
+		if m.event.WatchNamespace() != watched {
+			*cmd = tea.Batch(*cmd, m.restartEventWatch())
+		}
+	}
+}
+
+func (m *Model) updateNodeView(msg tea.Msg, cmd *tea.Cmd) {
+	keypress := msg.(tea.KeyMsg).String()
+	if m.node.Typing() {
+		keypress = ""
+	}
+	switch {
+	case keypress == "esc" && m.node.ShowPods:
+		m.node.ShowPods = false
+	case keypress == "esc":
+		*cmd = m.back()
+	default:
+		var nodeModel tea.Model
+		var c tea.Cmd
+		nodeModel, c = m.node.Update(msg)
+		*cmd = c
+		if n, ok := nodeModel.(nodes.Model); ok {
+			m.node = n
+		}
+	}
+}
+
+func (m *Model) updateServiceView(msg tea.Msg, cmd *tea.Cmd) {
+	keypress := msg.(tea.KeyMsg).String()
+	if m.service.Typing() {
+		keypress = ""
+	}
+	switch {
+	case keypress == "esc" && m.service.ShowPods:
+		m.service.ShowPods = false
+	case keypress == "esc":
+		*cmd = m.back()
+	default:
+		var svcModel tea.Model
+		var c tea.Cmd
+		svcModel, c = m.service.Update(msg)
+		*cmd = c
+		if svc, ok := svcModel.(services.Model); ok {
+			m.service = svc
+		}
+	}
+}
+
+func (m *Model) updateDescribeView(msg tea.Msg, cmd *tea.Cmd) {
+	keypress := msg.(tea.KeyMsg).String()
+	switch keypress {
+	case "esc":
+		*cmd = m.back()
+	default:
+		var descModel tea.Model
+		var c tea.Cmd
+		descModel, c = m.describe.Update(msg)
+		*cmd = c
+		if d, ok := descModel.(describe.Model); ok {
+			m.describe = d
+		}
+	}
+}
+
+func (m *Model) updateConfigView(msg tea.Msg, cmd *tea.Cmd) {
+	keypress := msg.(tea.KeyMsg).String()
+	switch {
+	case keypress == "esc" && m.config.ShowKeys:
+		m.config.ShowKeys = false
+	case keypress == "esc":
+		*cmd = m.back()
+	default:
+		var cfgModel tea.Model
+		var c tea.Cmd
+		cfgModel, c = m.config.Update(msg)
+		*cmd = c
+		if cfg, ok := cfgModel.(configs.Model); ok {
+			m.config = cfg
+		}
+	}
+}
+
+func (m *Model) updateJobView(msg tea.Msg, cmd *tea.Cmd) {
+	keypress := msg.(tea.KeyMsg).String()
+	switch {
+	case keypress == "esc" && m.job.Owner != "":
+		m.job.ClearOwner()
+	case keypress == "esc":
+		*cmd = m.back()
+	default:
+		var jobModel tea.Model
+		var c tea.Cmd
+		jobModel, c = m.job.Update(msg)
+		*cmd = c
+		if j, ok := jobModel.(jobs.Model); ok {
+			m.job = j
+		}
+	}
+}
+
+func (m *Model) updateIngressView(msg tea.Msg, cmd *tea.Cmd) {
+	keypress := msg.(tea.KeyMsg).String()
+	switch {
+	case keypress == "esc" && m.ingress.ShowRoutes:
+		m.ingress.ShowRoutes = false
+	case keypress == "esc":
+		*cmd = m.back()
+	default:
+		var ingModel tea.Model
+		var c tea.Cmd
+		ingModel, c = m.ingress.Update(msg)
+		*cmd = c
+		if ing, ok := ingModel.(ingresses.Model); ok {
+			m.ingress = ing
+		}
+	}
+}
+
+func (m *Model) updateStorageView(msg tea.Msg, cmd *tea.Cmd) {
+	keypress := msg.(tea.KeyMsg).String()
+	switch {
+	case keypress == "esc" && !m.storage.Resizing:
+		*cmd = m.back()
+	default:
+		var stModel tea.Model
+		var c tea.Cmd
+		stModel, c = m.storage.Update(msg)
+		*cmd = c
+		if st, ok := stModel.(storage.Model); ok {
+			m.storage = st
+		}
+	}
+}
+
+func (m *Model) updateXrayView(msg tea.Msg, cmd *tea.Cmd) {
+	keypress := msg.(tea.KeyMsg).String()
+	switch keypress {
+	case "esc":
+		*cmd = m.back()
+	default:
+		var xrayModel tea.Model
+		var c tea.Cmd
+		xrayModel, c = m.xray.Update(msg)
+		*cmd = c
+		if x, ok := xrayModel.(xray.Model); ok {
+			m.xray = x
+		}
+	}
+}
+
+func (m *Model) updateWorkloadView(msg tea.Msg, cmd *tea.Cmd) {
+	keypress := msg.(tea.KeyMsg).String()
+	switch keypress {
+	case "esc":
+		*cmd = m.back()
+	default:
+		var wlModel tea.Model
+		var c tea.Cmd
+		wlModel, c = m.workload.Update(msg)
+		*cmd = c
+		if wl, ok := wlModel.(workloads.Model); ok {
+			m.workload = wl
+		}
+	}
+}
+
+func (m *Model) updateResourceView(msg tea.Msg, cmd *tea.Cmd) {
+	keypress := msg.(tea.KeyMsg).String()
+	switch keypress {
+	case "esc":
+		*cmd = m.back()
+	default:
+		var resModel tea.Model
+		var c tea.Cmd
+		resModel, c = m.resource.Update(msg)
+		*cmd = c
+		if res, ok := resModel.(resources.Model); ok {
+			m.resource = res
+		}
+	}
+}
+
 func (m Model) View() string {
+	if m.palette.Active() {
+		return m.banner() + m.palette.View() + "\n" + m.view()
+	}
+	if m.exporter.Active() {
+		return m.banner() + m.exportView() + "\n" + m.view()
+	}
+	return m.banner() + m.view()
+}
+
+// banner Render the label of the context profile, the breadcrumbs and the last notice above every view
+func (m Model) banner() string {
+	badge := profileBadge.Background(theme.Error)
+	if m.profile.Color != "" {
+		badge = badge.Background(lipgloss.Color(m.profile.Color))
+	}
+	var parts []string
+	if m.profile.Label != "" {
+		parts = append(parts, badge.Render(m.profile.Label))
+	}
+	if m.profile.ReadOnly {
+		parts = append(parts, badge.Render("READ-ONLY"))
+	}
+	if info, ok := kubernetes.Snapshot(); ok {
+		parts = append(parts, badge.Render("SNAPSHOT "+info.Taken.Local().Format("2006-01-02 15:04")))
+	}
+	cs := m.crumbs()
+	cs[len(cs)-1] = crumbStyle.Render(cs[len(cs)-1])
+	parts = append(parts, strings.Join(cs, " / "))
+	if m.notice != "" {
+		parts = append(parts, noticeStyle.Render(m.notice))
+	}
+	return titleStyle.Render(strings.Join(parts, " ")) + "\n"
+}
+
+// header Render the context, the namespace and the warnings above the pods
+func (m Model) header() string {
 	var b strings.Builder
 	_, _ = fmt.Fprintf(&b, "CONTEXT: %s\n", m.context.SelectedContext.Name)
 	_, _ = fmt.Fprintf(&b, "NAMESPACE: %s\n", m.pod.Namespace)
-	s := titleStyle.Render(b.String()) + "\n\n"
+	if f := m.pod.FilterSummary(); f != "" {
+		_, _ = fmt.Fprintf(&b, "FILTER: %s\n", f)
+	}
+	header := titleStyle
+	if m.profile.Color != "" {
+		header = header.Foreground(lipgloss.Color(m.profile.Color))
+	}
+	s := header.Render(b.String())
+	if n := m.event.RecentWarnings(); n > 0 {
+		s = s + "\n" + titleStyle.Render(warningBadge.Render(fmt.Sprintf("%d WARNINGS", n)))
+	}
+	return s + "\n\n"
+}
+
+func (m Model) view() string {
+	s := m.header()
 	switch m.currentView {
 	case Pod:
+		if m.pane.on {
+			return s + m.splitView()
+		}
 		return s + m.pod.View()
 	case Context:
 		return m.context.View()
@@ -296,6 +1174,28 @@ func (m Model) View() string {
 		return m.namespace.View()
 	case Log:
 		return m.log.View()
+	case Event:
+		return m.event.View()
+	case Node:
+		return m.node.View()
+	case Service:
+		return m.service.View()
+	case Describe:
+		return m.describe.View()
+	case Config:
+		return m.config.View()
+	case Job:
+		return m.job.View()
+	case Ingress:
+		return m.ingress.View()
+	case Storage:
+		return m.storage.View()
+	case Xray:
+		return m.xray.View()
+	case Workload:
+		return m.workload.View()
+	case Resource:
+		return m.resource.View()
 	default:
 		return s
 	}
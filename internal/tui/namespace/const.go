@@ -1,6 +1,7 @@
 package namespace
 
 import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -11,7 +12,7 @@ const defaultWidth = 50
 var (
 	titleStyle        = lipgloss.NewStyle().MarginLeft(2).Bold(true)
 	itemStyle         = lipgloss.NewStyle().PaddingLeft(4)
-	selectedItemStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(lipgloss.Color("#FF7900"))
+	selectedItemStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(theme.Accent)
 	paginationStyle   = list.DefaultStyles().PaginationStyle.PaddingLeft(4)
 	helpStyle         = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
 )
@@ -40,4 +40,4 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 	}
 }
 
-func (i item) FilterValue() string { return "" }
+func (i item) FilterValue() string { return i.name }
@@ -17,3 +17,8 @@ var keys = KeyMap{
 		key.WithHelp("enter", "select"),
 	),
 }
+
+// Bindings Get the key map so the user configuration can remap it
+func Bindings() *KeyMap {
+	return &keys
+}
@@ -24,8 +24,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Namespaces.SetWidth(msg.Width)
 
 	case tea.KeyMsg:
-		switch keypress := msg.String(); keypress {
-		case "enter":
+		switch keypress := msg.String(); {
+		case m.Namespaces.FilterState() == list.Filtering:
+			m.Namespaces, cmd = m.Namespaces.Update(msg)
+		case keypress == "enter":
 			i, ok := m.Namespaces.SelectedItem().(item)
 			if ok {
 				m.SelectedNamespace = i.name
@@ -66,7 +68,7 @@ func buildNamespacesList() list.Model {
 	l := list.New(items, itemDelegate{}, defaultWidth, listHeight)
 	l.Title = "Select Namespace"
 	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(false)
+	l.SetFilteringEnabled(true)
 	l.Styles.Title = titleStyle
 	l.Styles.PaginationStyle = paginationStyle
 	l.Styles.HelpStyle = helpStyle
@@ -0,0 +1,205 @@
+package tui
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/configs"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/describe"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/ingresses"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/jobs"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/nodes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/pods"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/resources"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/services"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/storage"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/workloads"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/xray"
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+)
+
+// maxDepth caps the navigation stack, jumps can go around in circles
+const maxDepth = 20
+
+// frame is a view the user came from, kept with its model so going back shows it as it was left
+type frame struct {
+	view  Views
+	crumb string
+	// model is nil for views with a single live model, like events and logs
+	model tea.Model
+}
+
+// shortKinds are the kubectl short names used in breadcrumbs
+var shortKinds = map[string]string{
+	"Deployment":            "deploy",
+	"StatefulSet":           "sts",
+	"DaemonSet":             "ds",
+	"ReplicaSet":            "rs",
+	"Job":                   "job",
+	"CronJob":               "cronjob",
+	"Pod":                   "pod",
+	"Node":                  "node",
+	"Service":               "svc",
+	"Ingress":               "ing",
+	"ConfigMap":             "cm",
+	"Secret":                "secret",
+	"PersistentVolumeClaim": "pvc",
+	"PersistentVolume":      "pv",
+}
+
+// crumbOf Get the breadcrumb of an object, like deploy/api
+func crumbOf(ref kubernetes.ObjectRef) string {
+	kind, ok := shortKinds[ref.Kind]
+	if !ok {
+		kind = strings.ToLower(ref.Kind)
+	}
+	return kind + "/" + ref.Name
+}
+
+// push Remember the current view and show another one, call it before replacing the model of the next view
+func (m *Model) push(v Views, crumb string) {
+	m.stack = append(m.stack, frame{view: m.currentView, crumb: m.crumb, model: m.model(m.currentView)})
+	if len(m.stack) > maxDepth {
+		// Keep the root, the oldest drill-down goes
+		m.stack = append(m.stack[:1], m.stack[2:]...)
+	}
+	m.currentView = v
+	m.crumb = crumb
+}
+
+// back Show the previous view, the root stays
+func (m *Model) back() tea.Cmd {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.popTo(len(m.stack) - 1)
+}
+
+// popTo Go back to a level of the stack, 0 is the root
+func (m *Model) popTo(level int) tea.Cmd {
+	if level < 0 || level >= len(m.stack) {
+		return nil
+	}
+	if m.currentView == Log {
+		m.log.Stop()
+	}
+	f := m.stack[level]
+	m.stack = m.stack[:level]
+	watched := m.pod
+	m.restore(f)
+	if f.view != Pod {
+		return nil
+	}
+	// The pod view may come back to another namespace or selector than the watch follows
+	pods.RefreshPods(&m.pod, false)
+	if m.pod.Namespace != watched.Namespace || m.pod.ListOptions().LabelSelector != watched.ListOptions().LabelSelector ||
+		m.pod.ListOptions().FieldSelector != watched.ListOptions().FieldSelector {
+		return m.watchNamespace()
+	}
+	return nil
+}
+
+// reset Drop the stack and show the root pod view
+func (m *Model) reset() {
+	if len(m.stack) > 0 {
+		m.restore(m.stack[0])
+	}
+	m.stack = nil
+	m.currentView = Pod
+	m.crumb = ""
+}
+
+// model Get the model of a view to keep in a frame
+func (m Model) model(v Views) tea.Model {
+	switch v {
+	case Pod:
+		return m.pod
+	case Node:
+		return m.node
+	case Service:
+		return m.service
+	case Describe:
+		return m.describe
+	case Config:
+		return m.config
+	case Job:
+		return m.job
+	case Ingress:
+		return m.ingress
+	case Storage:
+		return m.storage
+	case Xray:
+		return m.xray
+	case Workload:
+		return m.workload
+	case Resource:
+		return m.resource
+	default:
+		return nil
+	}
+}
+
+// restore Show a frame again with the model it was left with
+func (m *Model) restore(f frame) {
+	m.currentView = f.view
+	m.crumb = f.crumb
+	switch model := f.model.(type) {
+	case pods.Model:
+		m.pod = model
+	case nodes.Model:
+		m.node = model
+	case services.Model:
+		m.service = model
+	case describe.Model:
+		m.describe = model
+	case configs.Model:
+		m.config = model
+	case jobs.Model:
+		m.job = model
+	case ingresses.Model:
+		m.ingress = model
+	case storage.Model:
+		m.storage = model
+	case xray.Model:
+		m.xray = model
+	case workloads.Model:
+		m.workload = model
+	case resources.Model:
+		m.resource = model
+	default:
+	}
+}
+
+// crumbed is a model with a drill-down of its own, like the pods of a node
+type crumbed interface {
+	Crumb() string
+}
+
+// crumbOfFrame Get the breadcrumb of a view with the drill-down it was left in
+func crumbOfFrame(crumb string, model tea.Model) string {
+	if c, ok := model.(crumbed); ok && c.Crumb() != "" {
+		return crumb + " " + c.Crumb()
+	}
+	return crumb
+}
+
+// crumbs Get the breadcrumbs: context, namespace, then one per view drilled into
+func (m Model) crumbs() []string {
+	cs := []string{m.context.SelectedContext.Name, m.pod.Namespace}
+	if len(m.stack) > 0 {
+		for _, f := range m.stack[1:] {
+			cs = append(cs, crumbOfFrame(f.crumb, f.model))
+		}
+		cs = append(cs, crumbOfFrame(m.crumb, m.model(m.currentView)))
+	}
+	return cs
+}
+
+// jump Go back to the view of a breadcrumb key, the first key is the namespace
+func (m *Model) jump(msg tea.KeyMsg) (tea.Cmd, bool) {
+	for i, k := range globalKeys.Jump.Keys() {
+		if msg.String() == k {
+			return m.popTo(i), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,20 @@
+package nodes
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const maxColumnWidth = 40
+const columnPadding = 2
+
+// drainPanelLines is how many progress lines the drain panel keeps on screen
+const drainPanelLines = 8
+
+var (
+	titleStyle  = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	statusStyle = lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Accent)
+	panelStyle  = lipgloss.NewStyle().MarginLeft(2).Padding(0, 1).Border(lipgloss.RoundedBorder()).BorderForeground(theme.Accent)
+	helpStyle   = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
+)
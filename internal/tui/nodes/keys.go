@@ -0,0 +1,58 @@
+package nodes
+
+import "github.com/charmbracelet/bubbles/key"
+
+type KeyMap struct {
+	Pods     key.Binding
+	Cordon   key.Binding
+	Uncordon key.Binding
+	Drain    key.Binding
+	Force    key.Binding
+	Refresh  key.Binding
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return append([]key.Binding{k.Pods, k.Cordon, k.Uncordon, k.Drain, k.Force, k.Refresh}, Plugins...)
+
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Pods, k.Cordon, k.Uncordon, k.Drain, k.Force, k.Refresh},
+	}
+}
+
+var keys = KeyMap{
+	Pods: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "pods"),
+	),
+	Cordon: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "cordon"),
+	),
+	Uncordon: key.NewBinding(
+		key.WithKeys("u"),
+		key.WithHelp("u", "uncordon"),
+	),
+	Drain: key.NewBinding(
+		key.WithKeys("d"),
+		key.WithHelp("d", "drain"),
+	),
+	Force: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "drain, deleting unmanaged pods"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
+}
+
+// Plugins are the user commands that apply to the view, set by LoadConfig
+var Plugins []key.Binding
+
+// Bindings Get the key map so the user configuration can remap it
+func Bindings() *KeyMap {
+	return &keys
+}
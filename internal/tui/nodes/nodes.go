@@ -0,0 +1,351 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/confirm"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/pods"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/usage"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	"strings"
+)
+
+type Model struct {
+	Nodes    table.Model
+	Help     help.Model
+	Pods     pods.Model
+	ShowPods bool
+	// ConfirmByName makes drains and pod deletions ask for the name, it follows the context profile
+	ConfirmByName bool
+	podsNode      string
+	items         []v1.Node
+	confirm       confirm.Model
+	drainNode     string
+	drainOpts     kubernetes.DrainOptions
+	// status tells why the last listing failed, notice what the last action did until the next key
+	status     string
+	notice     string
+	drain      string
+	drainLog   []string
+	progress   chan string
+	stopDrain  context.CancelFunc
+	metricsErr error
+}
+
+// DrainMsg carries a line of drain progress
+type DrainMsg string
+
+// DrainDoneMsg is sent once a drain has finished
+type DrainDoneMsg struct{}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Help.Width = msg.Width
+		m.Nodes.SetWidth(msg.Width)
+
+	case tea.KeyMsg:
+		if m.ShowPods {
+			var podModel tea.Model
+			podModel, cmd = m.Pods.Update(msg)
+			if pod, ok := podModel.(pods.Model); ok {
+				m.Pods = pod
+			}
+			return m, cmd
+		}
+		if m.confirm.Active() {
+			var ok bool
+			m.confirm, ok, cmd = m.confirm.Update(msg)
+			if ok {
+				cmd = m.startDrain(m.drainNode, m.drainOpts)
+			}
+			return m, cmd
+		}
+		m.notice = ""
+		n, ok := m.SelectedNode()
+		switch keypress := msg.String(); keypress {
+		case "enter":
+			if ok {
+				m.Pods = pods.NewForNode(n.Name)
+				m.Pods.ConfirmByName = m.ConfirmByName
+				m.podsNode = n.Name
+				m.ShowPods = true
+			}
+		case "c", "u":
+			if ok {
+				m.cordon(n.Name, keypress == "c")
+			}
+		case "d", "D":
+			if ok && m.progress == nil {
+				cmd = m.confirmDrain(n.Name, keypress == "D")
+			}
+		case "r":
+			RefreshNodes(&m)
+		default:
+			m.Nodes, cmd = m.Nodes.Update(msg)
+		}
+	case pods.MetricsTickMsg:
+		if m.ShowPods {
+			var podModel tea.Model
+			podModel, cmd = m.Pods.Update(msg)
+			if pod, ok := podModel.(pods.Model); ok {
+				m.Pods = pod
+			}
+		}
+	case DrainMsg:
+		if m.progress == nil {
+			break
+		}
+		m.drainLog = append(m.drainLog, string(msg))
+		cmd = WatchDrain(m.progress)
+	case DrainDoneMsg:
+		m.progress = nil
+		m.stopDrain = nil
+		m.notice = fmt.Sprintf("drain of node/%s finished", m.drain)
+		RefreshNodes(&m)
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if m.ShowPods {
+		return "\n" + titleStyle.Render("Pods on node/"+m.podsNode) + "\n\n" + m.Pods.View()
+	}
+	var b strings.Builder
+	b.WriteString("\n" + titleStyle.Render("Nodes") + "\n\n")
+	b.WriteString(m.Nodes.View() + "\n")
+	if len(m.drainLog) > 0 {
+		lines := m.drainLog[max(0, len(m.drainLog)-drainPanelLines):]
+		b.WriteString(panelStyle.Render("Draining node/"+m.drain+"\n"+strings.Join(lines, "\n")) + "\n")
+	}
+	if m.confirm.Active() {
+		b.WriteString(m.confirm.View() + "\n")
+	} else {
+		for _, s := range []string{m.notice, m.status} {
+			if s != "" {
+				b.WriteString(statusStyle.Render(s) + "\n")
+			}
+		}
+		if m.metricsErr != nil {
+			b.WriteString(statusStyle.Render("metrics: "+m.metricsErr.Error()) + "\n")
+		}
+	}
+	b.WriteString(helpStyle.Render(m.Help.View(keys)))
+	return b.String()
+}
+
+// Crumb Get the breadcrumb of the drill-down, empty when showing the nodes
+func (m Model) Crumb() string {
+	if m.ShowPods {
+		return "node/" + m.podsNode
+	}
+	return ""
+}
+
+// Typing Tell whether keys go to a text input, the drain confirmation or a pod prompt
+func (m Model) Typing() bool {
+	if m.ShowPods {
+		return m.Pods.Typing()
+	}
+	return m.confirm.Typing()
+}
+
+// SelectedNode Get the node under the cursor
+func (m Model) SelectedNode() (v1.Node, bool) {
+	c := m.Nodes.Cursor()
+	if c < 0 || c >= len(m.items) {
+		return v1.Node{}, false
+	}
+	return m.items[c], true
+}
+
+// Select Move the cursor to the named node
+func (m *Model) Select(name string) {
+	for i, n := range m.items {
+		if n.Name == name {
+			m.Nodes.SetCursor(i)
+			return
+		}
+	}
+}
+
+// Loaded Tell whether the nodes were listed at least once
+func (m Model) Loaded() bool {
+	return len(m.Nodes.Columns()) > 0
+}
+
+func (m *Model) cordon(node string, unschedulable bool) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	if err := kubernetes.CordonNode(ctx, node, unschedulable); err != nil {
+		m.notice = err.Error()
+		return
+	}
+	if unschedulable {
+		m.notice = fmt.Sprintf("node/%s cordoned", node)
+	} else {
+		m.notice = fmt.Sprintf("node/%s uncordoned", node)
+	}
+	RefreshNodes(m)
+}
+
+// confirmDrain Ask before draining a node, naming the pods it skips or, forced, deletes for good
+func (m *Model) confirmDrain(node string, force bool) tea.Cmd {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	plan, err := kubernetes.PlanDrain(ctx, node)
+	if err != nil {
+		m.notice = err.Error()
+		return nil
+	}
+	question := "Drain node/" + node
+	switch n := len(plan.Unmanaged) + len(plan.EmptyDir); {
+	case n == 0:
+	case force:
+		question += fmt.Sprintf(", deleting %d unmanaged pods and %d pods with emptyDir data", len(plan.Unmanaged), len(plan.EmptyDir))
+	default:
+		question += fmt.Sprintf(", skipping %d unmanaged or emptyDir pods (D deletes them)", n)
+	}
+	m.drainNode = node
+	m.drainOpts = kubernetes.DrainOptions{Force: force, DeleteEmptyDirData: force}
+	var cmd tea.Cmd
+	m.confirm, cmd = confirm.New(question, node, m.ConfirmByName)
+	return cmd
+}
+
+// startDrain Drain a node in the background, progress arrives as DrainMsg until StopDrain
+func (m *Model) startDrain(node string, opts kubernetes.DrainOptions) tea.Cmd {
+	m.drain = node
+	m.drainLog = nil
+	m.notice = ""
+	m.progress = make(chan string, drainPanelLines)
+	progress := m.progress
+	// The drain stays on this cluster when another tab is shown
+	ctx, cancel := context.WithCancel(kubernetes.Bind(context.Background()))
+	m.stopDrain = cancel
+	go func() {
+		defer cancel()
+		defer close(progress)
+		if err := kubernetes.DrainNode(ctx, node, opts, progress); err != nil {
+			select {
+			case progress <- "error: " + err.Error():
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return WatchDrain(progress)
+}
+
+// StopDrain Abort the drain under way, when nothing will read its progress anymore
+func (m *Model) StopDrain() {
+	if m.stopDrain != nil {
+		m.stopDrain()
+	}
+}
+
+func WatchDrain(progress <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-progress
+		if !ok {
+			return DrainDoneMsg{}
+		}
+		return DrainMsg(line)
+	}
+}
+
+func RefreshNodes(m *Model) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	m.status = ""
+	nds, err := kubernetes.GetNodes(ctx)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	// Requests are only counted when every pod can be listed
+	var reqs map[string]v1.ResourceList
+	if pds, err := kubernetes.GetPods(ctx, ""); err != nil {
+		m.status = "requests: " + err.Error()
+	} else {
+		reqs = kubernetes.NodeRequests(pds)
+	}
+
+	columns := []table.Column{
+		{Title: "NAME"}, {Title: "STATUS"}, {Title: "ROLES"}, {Title: "VERSION"}, {Title: "AGE"},
+		{Title: "CPU REQ/ALLOC"}, {Title: "MEM REQ/ALLOC"}, {Title: "PRESSURE"}, {Title: "TAINTS"},
+	}
+	allocation := func(name v1.ResourceName, n v1.Node) string {
+		if reqs == nil {
+			return usage.Unavailable
+		}
+		return kubernetes.ColumnHelperAllocation(name, reqs[n.Name], n.Status.Allocatable)
+	}
+	var rows []table.Row
+	for _, n := range nds {
+		rows = append(rows, table.Row{
+			n.Name,
+			kubernetes.ColumnHelperNodeStatus(n),
+			kubernetes.ColumnHelperNodeRoles(n),
+			n.Status.NodeInfo.KubeletVersion,
+			kubernetes.ColumnHelperAge(n.CreationTimestamp),
+			allocation(v1.ResourceCPU, n),
+			allocation(v1.ResourceMemory, n),
+			kubernetes.ColumnHelperPressure(n),
+			kubernetes.ColumnHelperTaints(n),
+		})
+	}
+	for j := range columns {
+		columns[j].Width = len(columns[j].Title)
+		for _, r := range rows {
+			columns[j].Width = min(max(columns[j].Width, len(r[j])), config.ColumnWidth(maxColumnWidth))
+		}
+		columns[j].Width += columnPadding
+	}
+
+	used, err := kubernetes.GetNodeUsage(ctx)
+	m.metricsErr = err
+	columns = usage.AddColumns(columns, rows, columnPadding, []string{"CPU USE", "MEM USE"}, func(i int) []string {
+		n := nds[i]
+		return []string{nodeUsage(v1.ResourceCPU, used[n.Name], n.Status.Allocatable), nodeUsage(v1.ResourceMemory, used[n.Name], n.Status.Allocatable)}
+	})
+
+	m.items = nds
+	m.Nodes.SetRows(nil)
+	m.Nodes.SetColumns(columns)
+	m.Nodes.SetRows(rows)
+}
+
+// nodeUsage Render node usage with its percent of allocatable
+func nodeUsage(name v1.ResourceName, used v1.ResourceList, allocatable v1.ResourceList) string {
+	u, ok := used[name]
+	if !ok {
+		return usage.Unavailable
+	}
+	return kubernetes.FormatQuantity(name, u) + " " + usage.Percent(name, used, allocatable)
+}
+
+func New() Model {
+	t := table.New(
+		table.WithFocused(true),
+	)
+
+	t.SetStyles(theme.TableStyles())
+
+	m := Model{
+		Nodes: t,
+		Help:  help.New(),
+	}
+	RefreshNodes(&m)
+	return m
+}
@@ -0,0 +1,153 @@
+package palette
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Command is a parsed command line, like "deploy api -n payments"
+type Command struct {
+	// Name is the canonical name of the command, like "deploy" for "deployments"
+	Name string
+	// Kind is the API kind the command shows, empty for ctx, tab and quit
+	Kind string
+	// Arg is the object, context or namespace name, empty to list them
+	Arg           string
+	Namespace     string
+	AllNamespaces bool
+	Container     string
+	// Resource is the word of a command the line doesn't know, a resource the server may have, like certificates
+	Resource string
+}
+
+// verb is a command and the names it answers to
+type verb struct {
+	name    string
+	aliases []string
+	kind    string
+}
+
+var verbs = []verb{
+	{"pods", []string{"po", "pod"}, "Pod"},
+	{"deploy", []string{"deployment", "deployments"}, "Deployment"},
+	{"sts", []string{"statefulset", "statefulsets"}, "StatefulSet"},
+	{"ds", []string{"daemonset", "daemonsets"}, "DaemonSet"},
+	{"svc", []string{"service", "services"}, "Service"},
+	{"cm", []string{"configmap", "configmaps"}, "ConfigMap"},
+	{"secrets", []string{"secret"}, "Secret"},
+	{"jobs", []string{"job"}, "Job"},
+	{"cronjobs", []string{"cj", "cronjob"}, "CronJob"},
+	{"ing", []string{"ingress", "ingresses"}, "Ingress"},
+	{"pvc", []string{"persistentvolumeclaim", "persistentvolumeclaims"}, "PersistentVolumeClaim"},
+	{"pv", []string{"persistentvolume", "persistentvolumes"}, "PersistentVolume"},
+	{"nodes", []string{"no", "node"}, "Node"},
+	{"events", []string{"ev", "event"}, "Event"},
+	{"ctx", []string{"context", "contexts"}, ""},
+	{"tab", []string{"tabs"}, ""},
+	{"ns", []string{"namespace", "namespaces"}, "Namespace"},
+	{"logs", []string{"log"}, "Pod"},
+	{"quit", []string{"q"}, ""},
+}
+
+// lookup Find a command by any of its names
+func lookup(name string) (verb, bool) {
+	for _, v := range verbs {
+		if v.name == name {
+			return v, true
+		}
+		for _, a := range v.aliases {
+			if a == name {
+				return v, true
+			}
+		}
+	}
+	return verb{}, false
+}
+
+// Names Get every name a command answers to and the user aliases, sorted
+func Names(aliases map[string]string) []string {
+	var names []string
+	for _, v := range verbs {
+		names = append(names, v.name)
+		names = append(names, v.aliases...)
+	}
+	for a := range aliases {
+		names = append(names, a)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// expand Replace a user alias by its command, once so aliases can't loop
+func expand(fields []string, aliases map[string]string) []string {
+	if len(fields) == 0 {
+		return fields
+	}
+	if a, ok := aliases[fields[0]]; ok {
+		return append(strings.Fields(strings.TrimPrefix(a, ":")), fields[1:]...)
+	}
+	return fields
+}
+
+// Parse Read a command line, user aliases expand in place of the first word
+func Parse(line string, aliases map[string]string) (Command, error) {
+	fields := expand(strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), ":")), aliases)
+	if len(fields) == 0 {
+		return Command{}, errors.New("empty command")
+	}
+	c := Command{Name: "resource", Resource: fields[0]}
+	if v, ok := lookup(fields[0]); ok {
+		c = Command{Name: v.name, Kind: v.kind}
+	}
+	var args []string
+	for i := 1; i < len(fields); i++ {
+		f := fields[i]
+		name, value, inline := strings.Cut(f, "=")
+		switch name {
+		case "-A", "--all-namespaces":
+			c.AllNamespaces = true
+			continue
+		case "-n", "--namespace", "-c", "--container":
+		default:
+			args = append(args, f)
+			continue
+		}
+		if !inline {
+			if i+1 >= len(fields) {
+				return Command{}, fmt.Errorf("%s needs a value", name)
+			}
+			i++
+			value = fields[i]
+		}
+		if name == "-n" || name == "--namespace" {
+			c.Namespace = value
+		} else {
+			c.Container = value
+		}
+	}
+
+	switch {
+	case len(args) > 1:
+		return Command{}, fmt.Errorf("%s takes one name, got %s", c.Name, strings.Join(args, " "))
+	case len(args) == 1:
+		c.Arg = args[0]
+	}
+	if c.Container != "" && c.Name != "logs" {
+		return Command{}, errors.New("-c only applies to logs")
+	}
+	if c.Name == "resource" && c.Arg != "" {
+		return Command{}, fmt.Errorf("%s only lists, it takes no name", c.Resource)
+	}
+	if c.Name == "logs" && c.Arg == "" {
+		return Command{}, errors.New("logs needs a pod name")
+	}
+	if c.AllNamespaces && c.Arg != "" {
+		return Command{}, errors.New("-A lists every namespace, it takes no name")
+	}
+	if c.AllNamespaces && c.Namespace != "" {
+		return Command{}, errors.New("-A and -n don't go together")
+	}
+	return c, nil
+}
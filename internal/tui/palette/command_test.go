@@ -0,0 +1,68 @@
+package palette
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	aliases := map[string]string{"pp": ":pods -n payments", "api": "logs api-7f9"}
+	tests := []struct {
+		line string
+		want Command
+	}{
+		{"pods", Command{Name: "pods", Kind: "Pod"}},
+		{":  po  ", Command{Name: "pods", Kind: "Pod"}},
+		{"deployments api -n shop", Command{Name: "deploy", Kind: "Deployment", Arg: "api", Namespace: "shop"}},
+		{"deploy -n=shop", Command{Name: "deploy", Kind: "Deployment", Namespace: "shop"}},
+		{"svc --namespace shop", Command{Name: "svc", Kind: "Service", Namespace: "shop"}},
+		{"pods -A", Command{Name: "pods", Kind: "Pod", AllNamespaces: true}},
+		{"logs api-7f9 -c app", Command{Name: "logs", Kind: "Pod", Arg: "api-7f9", Container: "app"}},
+		{"logs --container=app api-7f9", Command{Name: "logs", Kind: "Pod", Arg: "api-7f9", Container: "app"}},
+		{"tab production", Command{Name: "tab", Arg: "production"}},
+		{"q", Command{Name: "quit"}},
+		{"certificates -n shop", Command{Name: "resource", Resource: "certificates", Namespace: "shop"}},
+		{"pp", Command{Name: "pods", Kind: "Pod", Namespace: "payments"}},
+		{"pp api-7f9", Command{Name: "pods", Kind: "Pod", Arg: "api-7f9", Namespace: "payments"}},
+		{"api -c proxy", Command{Name: "logs", Kind: "Pod", Arg: "api-7f9", Container: "proxy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := Parse(tt.line, aliases)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	// Aliases expand once, loop ends up an unknown resource instead of looping
+	aliases := map[string]string{"loop": "loop"}
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"", "empty command"},
+		{":", "empty command"},
+		{"pods -n", "-n needs a value"},
+		{"pods api web", "pods takes one name, got api web"},
+		{"pods api -c app", "-c only applies to logs"},
+		{"svc -c=app", "-c only applies to logs"},
+		{"logs", "logs needs a pod name"},
+		{"pods api -A", "-A lists every namespace, it takes no name"},
+		{"pods -A -n shop", "-A and -n don't go together"},
+		{"certificates cert-1", "certificates only lists, it takes no name"},
+		{"loop x", "loop only lists, it takes no name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := Parse(tt.line, aliases)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Parse = %+v, %v, want error %q", got, err, tt.want)
+			}
+		})
+	}
+}
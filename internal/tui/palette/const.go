@@ -0,0 +1,19 @@
+package palette
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/lipgloss"
+	"time"
+)
+
+// completionTimeout bounds the calls listing names, tab must stay snappy
+const completionTimeout = 3 * time.Second
+
+// maxMatches is how many candidates show under the command line
+const maxMatches = 12
+
+var (
+	promptStyle = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	errorStyle  = lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Error)
+	matchStyle  = lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Accent)
+)
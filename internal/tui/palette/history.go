@@ -0,0 +1,60 @@
+package palette
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/client-go/util/homedir"
+)
+
+// historySize is how many commands are kept across sessions
+const historySize = 500
+
+// historyPath Get where the command history lives, under $XDG_STATE_HOME like other state
+func historyPath() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		dir = filepath.Join(homedir.HomeDir(), ".local", "state")
+	}
+	return filepath.Join(dir, "k8s-manager", "history")
+}
+
+// loadHistory Read the commands of past sessions, oldest first
+func loadHistory(path string) []string {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		// No history yet, or one we can't read, starts empty
+		return nil
+	}
+	var h []string
+	for _, l := range strings.Split(string(raw), "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			h = append(h, l)
+		}
+	}
+	return trimHistory(h)
+}
+
+// saveHistory Write the history for the next sessions
+func saveHistory(path string, h []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(strings.Join(h, "\n")+"\n"), 0o600)
+}
+
+// addHistory Append a command, a repeat of the last one is kept once
+func addHistory(h []string, line string) []string {
+	if len(h) > 0 && h[len(h)-1] == line {
+		return h
+	}
+	return trimHistory(append(h, line))
+}
+
+func trimHistory(h []string) []string {
+	if len(h) > historySize {
+		return h[len(h)-historySize:]
+	}
+	return h
+}
@@ -0,0 +1,253 @@
+// Package palette is the : command line. It parses commands like "deploy -n kube-system",
+// completes kinds, names, namespaces and contexts with tab, and keeps a history across sessions.
+package palette
+
+import (
+	"context"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+)
+
+// CommandMsg carries a command the user entered
+type CommandMsg struct {
+	Command Command
+}
+
+type Model struct {
+	input  textinput.Model
+	active bool
+	// namespace is where names complete when the line has no -n
+	namespace string
+	history   []string
+	histPath  string
+	// pos is the history entry shown, len(history) is the line being typed
+	pos   int
+	draft string
+	// matches are the candidates of the last tab when there were several
+	matches []string
+	err     error
+	cache   map[string][]string
+}
+
+// flags are the options commands take, offered when a word starts with -
+var flags = []string{"--all-namespaces", "--container", "--namespace", "-A", "-c", "-n"}
+
+// New Get a closed command line with the history of past sessions
+func New() Model {
+	ti := textinput.New()
+	ti.Prompt = ":"
+	path := historyPath()
+	return Model{input: ti, histPath: path, history: loadHistory(path)}
+}
+
+// Open Start typing a command, names complete in the namespace unless the line has -n
+func (m *Model) Open(namespace string) tea.Cmd {
+	m.active = true
+	m.namespace = namespace
+	m.input.SetValue("")
+	m.pos = len(m.history)
+	m.draft = ""
+	m.matches = nil
+	m.err = nil
+	m.cache = map[string][]string{}
+	return m.input.Focus()
+}
+
+// Active Tell whether the command line takes the keys
+func (m Model) Active() bool {
+	return m.active
+}
+
+func (m *Model) close() {
+	m.active = false
+	m.input.Blur()
+}
+
+// Update Handle a key, enter sends a CommandMsg once the line parses
+func (m Model) Update(msg tea.KeyMsg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg.String() {
+	case "esc":
+		m.close()
+	case "enter":
+		line := strings.TrimSpace(m.input.Value())
+		if line == "" {
+			m.close()
+			break
+		}
+		c, err := Parse(line, config.Current.Aliases)
+		if err != nil {
+			m.err = err
+			break
+		}
+		m.history = addHistory(m.history, line)
+		// History is a convenience, failing to save it must not get in the way of the command
+		_ = saveHistory(m.histPath, m.history)
+		m.close()
+		cmd = func() tea.Msg { return CommandMsg{Command: c} }
+	case "tab":
+		m.complete()
+	case "up":
+		if m.pos > 0 {
+			if m.pos == len(m.history) {
+				m.draft = m.input.Value()
+			}
+			m.pos--
+			m.show(m.history[m.pos])
+		}
+	case "down":
+		if m.pos < len(m.history) {
+			m.pos++
+			if m.pos == len(m.history) {
+				m.show(m.draft)
+			} else {
+				m.show(m.history[m.pos])
+			}
+		}
+	default:
+		m.matches = nil
+		m.err = nil
+		m.input, cmd = m.input.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m *Model) show(line string) {
+	m.input.SetValue(line)
+	m.input.CursorEnd()
+	m.matches = nil
+	m.err = nil
+}
+
+// complete Complete the word under the cursor, to the common prefix when several candidates match
+func (m *Model) complete() {
+	line := m.input.Value()
+	fields := strings.Fields(line)
+	word := ""
+	if len(fields) > 0 && !strings.HasSuffix(line, " ") {
+		word, fields = fields[len(fields)-1], fields[:len(fields)-1]
+	}
+	var matches []string
+	for _, c := range m.candidates(fields, word) {
+		if strings.HasPrefix(c, word) {
+			matches = append(matches, c)
+		}
+	}
+	m.matches = nil
+	if len(matches) == 0 {
+		return
+	}
+	done := commonPrefix(matches)
+	if len(matches) == 1 {
+		done += " "
+	} else {
+		m.matches = matches
+	}
+	m.input.SetValue(strings.Join(append(fields, done), " "))
+	m.input.CursorEnd()
+}
+
+// candidates Get what the word after fields can be
+func (m *Model) candidates(fields []string, word string) []string {
+	if len(fields) == 0 {
+		return Names(config.Current.Aliases)
+	}
+	if strings.HasPrefix(word, "-") {
+		return flags
+	}
+	fields = expand(fields, config.Current.Aliases)
+	v, ok := lookup(fields[0])
+	if !ok {
+		return nil
+	}
+	ns, arg := m.namespace, ""
+	for i := 1; i < len(fields); i++ {
+		switch fields[i] {
+		case "-n", "--namespace", "-c", "--container":
+			if i+1 < len(fields) && (fields[i] == "-n" || fields[i] == "--namespace") {
+				ns = fields[i+1]
+			}
+			i++
+		case "-A", "--all-namespaces":
+		default:
+			if !strings.HasPrefix(fields[i], "-") {
+				arg = fields[i]
+			}
+		}
+	}
+	switch last := fields[len(fields)-1]; {
+	case last == "-n" || last == "--namespace":
+		return m.names("Namespace", "")
+	case (last == "-c" || last == "--container") && v.name == "logs" && arg != "":
+		return m.containers(ns, arg)
+	case arg != "":
+		// The name is already there, only flags can follow
+		return nil
+	}
+	switch v.name {
+	case "ctx", "tab":
+		return kubernetes.ContextNames()
+	case "events", "quit":
+		return nil
+	default:
+		return m.names(v.kind, ns)
+	}
+}
+
+// names List the names of a kind, once per kind and namespace while the line is open
+func (m *Model) names(kind string, namespace string) []string {
+	key := kind + "/" + namespace
+	if names, ok := m.cache[key]; ok {
+		return names
+	}
+	ctx, cancelFunc := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancelFunc()
+	names, err := kubernetes.ListNames(ctx, kind, namespace)
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	m.cache[key] = names
+	return names
+}
+
+func (m *Model) containers(namespace string, pod string) []string {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancelFunc()
+	names, err := kubernetes.ContainerNames(ctx, namespace, pod)
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	return names
+}
+
+func commonPrefix(words []string) string {
+	p := words[0]
+	for _, w := range words[1:] {
+		for !strings.HasPrefix(w, p) {
+			p = p[:len(p)-1]
+		}
+	}
+	return p
+}
+
+func (m Model) View() string {
+	s := promptStyle.Render(m.input.View())
+	if m.err != nil {
+		s = s + "\n" + errorStyle.Render(m.err.Error())
+	}
+	if len(m.matches) > 0 {
+		shown := m.matches
+		more := ""
+		if len(shown) > maxMatches {
+			shown, more = shown[:maxMatches], fmt.Sprintf("  ... %d more", len(m.matches)-maxMatches)
+		}
+		s = s + "\n" + matchStyle.Render(strings.Join(shown, "  ")+more)
+	}
+	return s
+}
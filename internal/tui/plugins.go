@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/configs"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/ingresses"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/jobs"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/keymap"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/nodes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/pods"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/services"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/storage"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/workloads"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/xray"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// pluginDoneMsg is sent when a plugin command exits
+type pluginDoneMsg struct {
+	plugin config.Plugin
+	output string
+	err    error
+}
+
+// pluginViews are the views plugins can run from, with the kinds they select and their help
+var pluginViews = map[Views]struct {
+	kinds []string
+	help  *[]key.Binding
+}{
+	Pod:      {[]string{"Pod"}, &pods.Plugins},
+	Workload: {kubernetes.WorkloadKinds, &workloads.Plugins},
+	Service:  {[]string{"Service"}, &services.Plugins},
+	Node:     {[]string{"Node"}, &nodes.Plugins},
+	Job:      {[]string{"Job", "CronJob"}, &jobs.Plugins},
+	Ingress:  {[]string{"Ingress"}, &ingresses.Plugins},
+	Config:   {[]string{"ConfigMap", "Secret"}, &configs.Plugins},
+	Storage:  {[]string{"PersistentVolumeClaim", "PersistentVolume"}, &storage.Plugins},
+	Xray:     {[]string{"Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Pod", "Job", "CronJob"}, &xray.Plugins},
+}
+
+// bindPlugins Add the plugins to the help of the views they apply to, a key the view already uses is an error
+func bindPlugins() []error {
+	var errs []error
+	taken := map[Views]map[string]string{}
+	for _, v := range viewKeys {
+		taken[v.view] = keymap.Keys(v.keys)
+	}
+	global := keymap.Keys(&globalKeys)
+	for v, pv := range pluginViews {
+		*pv.help = nil
+		for i, p := range config.Current.Plugins {
+			if !slices.ContainsFunc(pv.kinds, p.Applies) {
+				continue
+			}
+			field := fmt.Sprintf("plugins[%d].key", i)
+			if a, ok := global[p.Key]; ok {
+				errs = append(errs, fmt.Errorf("%s: %q is already bound to %s", field, p.Key, a))
+				continue
+			}
+			if a, ok := taken[v][p.Key]; ok {
+				errs = append(errs, fmt.Errorf("%s: %q is already bound to %s", field, p.Key, a))
+				continue
+			}
+			if !keymap.Valid(p.Key) {
+				errs = append(errs, fmt.Errorf("%s: %q is not a key", field, p.Key))
+				continue
+			}
+			taken[v][p.Key] = p.Description
+			*pv.help = append(*pv.help, key.NewBinding(key.WithKeys(p.Key), key.WithHelp(p.Key, p.Description)))
+		}
+	}
+	// Several views share the same failure, report it once
+	slices.SortFunc(errs, func(a, b error) int { return strings.Compare(a.Error(), b.Error()) })
+	return slices.CompactFunc(errs, func(a, b error) bool { return a.Error() == b.Error() })
+}
+
+// pluginOn Get the plugin a key runs on a view
+func pluginOn(v Views, k string) (config.Plugin, bool) {
+	pv, ok := pluginViews[v]
+	if !ok {
+		return config.Plugin{}, false
+	}
+	for _, p := range config.Current.Plugins {
+		if p.Key == k && slices.ContainsFunc(pv.kinds, p.Applies) {
+			return p, true
+		}
+	}
+	return config.Plugin{}, false
+}
+
+// selection Get the object under the cursor of the current view and the default container of a pod
+func (m Model) selection() (kubernetes.ObjectRef, string, bool) {
+	if m.keyView() == Pod {
+		pm := m.pod
+		switch m.currentView {
+		case Node:
+			pm = m.node.Pods
+		case Service:
+			pm = m.service.Pods
+		default:
+		}
+		p, ok := pm.SelectedPod()
+		if !ok {
+			return kubernetes.ObjectRef{}, "", false
+		}
+		return kubernetes.ObjectRef{Kind: "Pod", Namespace: p.Namespace, Name: p.Name}, kubernetes.DefaultContainer(*p), true
+	}
+	var ref kubernetes.ObjectRef
+	ok := false
+	switch m.currentView {
+	case Workload:
+		ref, ok = m.workload.Ref()
+	case Service:
+		var svc v1.Service
+		svc, ok = m.service.SelectedService()
+		ref = kubernetes.ObjectRef{Kind: "Service", Namespace: svc.Namespace, Name: svc.Name}
+	case Node:
+		var n v1.Node
+		n, ok = m.node.SelectedNode()
+		ref = kubernetes.ObjectRef{Kind: "Node", Name: n.Name}
+	case Job:
+		ref, ok = m.job.Ref()
+	case Ingress:
+		ref, ok = m.ingress.Ref()
+	case Config:
+		ref, ok = m.config.Ref()
+	case Storage:
+		ref, ok = m.storage.Ref()
+	case Xray:
+		var n *kubernetes.XrayNode
+		if n, ok = m.xray.Selected(); ok {
+			ref = n.ObjectRef
+		}
+	default:
+	}
+	return ref, "", ok
+}
+
+// plugin Run the plugin bound to a key on the selected object, false when none applies
+func (m Model) plugin(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if _, ok := pluginViews[m.keyView()]; !ok {
+		return nil, false
+	}
+	for _, p := range config.Current.Plugins {
+		if p.Key != msg.String() {
+			continue
+		}
+		ref, container, ok := m.selection()
+		if !ok || !p.Applies(ref.Kind) {
+			continue
+		}
+		return m.runPlugin(p, ref, container), true
+	}
+	return nil, false
+}
+
+// runPlugin Run the command of a plugin, in the background or in the terminal
+func (m Model) runPlugin(p config.Plugin, ref kubernetes.ObjectRef, container string) tea.Cmd {
+	line, err := p.Render(config.PluginVars{
+		Namespace: ref.Namespace,
+		Name:      ref.Name,
+		Kind:      ref.Kind,
+		Context:   m.context.SelectedContext.Name,
+		Container: container,
+	})
+	if err != nil {
+		return func() tea.Msg { return pluginDoneMsg{plugin: p, err: err} }
+	}
+	c := exec.Command("sh", "-c", line)
+	if p.Background {
+		return func() tea.Msg {
+			out, err := c.CombinedOutput()
+			return pluginDoneMsg{plugin: p, output: string(out), err: err}
+		}
+	}
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		return pluginDoneMsg{plugin: p, err: err}
+	})
+}
+
+// pluginNotice Get the notice a plugin leaves, the last line it printed tells why it failed
+func pluginNotice(msg pluginDoneMsg) string {
+	if msg.err == nil {
+		if msg.plugin.Background {
+			return msg.plugin.Description + ": done"
+		}
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(msg.output), "\n")
+	if last := lines[len(lines)-1]; last != "" {
+		return fmt.Sprintf("%s: %v: %s", msg.plugin.Description, msg.err, last)
+	}
+	return fmt.Sprintf("%s: %v", msg.plugin.Description, msg.err)
+}
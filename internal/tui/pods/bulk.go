@@ -0,0 +1,188 @@
+package pods
+
+import (
+	"context"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/confirm"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"strings"
+)
+
+// BulkDoneMsg carries the per-pod results of a bulk action
+type BulkDoneMsg struct {
+	Action  string
+	Results []kubernetes.BulkResult
+}
+
+// toggleMark Mark or unmark the pod under the cursor and move to the next row
+func (m *Model) toggleMark() {
+	p, ok := m.SelectedPod()
+	if !ok {
+		return
+	}
+	if m.marked[p.UID] {
+		delete(m.marked, p.UID)
+	} else {
+		m.marked[p.UID] = true
+	}
+	m.render()
+	m.Pods.MoveDown(1)
+}
+
+// markAll Mark every pod matching the filters
+func (m *Model) markAll() {
+	for _, p := range m.items {
+		m.marked[p.UID] = true
+	}
+	m.render()
+}
+
+// invertMarks Flip the mark of every pod matching the filters
+func (m *Model) invertMarks() {
+	for _, p := range m.items {
+		if m.marked[p.UID] {
+			delete(m.marked, p.UID)
+		} else {
+			m.marked[p.UID] = true
+		}
+	}
+	m.render()
+}
+
+// Marked Get the marked pods the filters show, the pod under the cursor when none are marked.
+// Marked pods a filter hides are left alone, bulk actions only touch what is on screen
+func (m Model) Marked() []v1.Pod {
+	var pds []v1.Pod
+	for _, p := range m.items {
+		if m.marked[p.UID] {
+			pds = append(pds, *p)
+		}
+	}
+	if len(pds) == 0 {
+		if p, ok := m.SelectedPod(); ok {
+			pds = append(pds, *p)
+		}
+	}
+	return pds
+}
+
+// hiddenMarks Count the marked pods the filters hide
+func (m Model) hiddenMarks() int {
+	shown := 0
+	for _, p := range m.items {
+		if m.marked[p.UID] {
+			shown++
+		}
+	}
+	return len(m.marked) - shown
+}
+
+// pruneMarks Forget marks of pods that are gone
+func (m *Model) pruneMarks() {
+	if len(m.marked) == 0 {
+		return
+	}
+	seen := map[types.UID]bool{}
+	for _, r := range m.table.Rows {
+		if p, ok := kubernetes.TablePod(r); ok {
+			seen[p.UID] = true
+		}
+	}
+	for uid := range m.marked {
+		if !seen[uid] {
+			delete(m.marked, uid)
+		}
+	}
+}
+
+// openDeletePrompt Ask before deleting the marked pods, by pod name or count when typed
+func (m *Model) openDeletePrompt() tea.Cmd {
+	m.bulk = m.Marked()
+	if len(m.bulk) == 0 {
+		return nil
+	}
+	m.prompt = deletePrompt
+	question, name := fmt.Sprintf("Delete %d pods", len(m.bulk)), fmt.Sprintf("%d pods", len(m.bulk))
+	if len(m.bulk) == 1 {
+		question, name = "Delete pod/"+m.bulk[0].Name, m.bulk[0].Name
+	}
+	if hidden := m.hiddenMarks(); hidden > 0 {
+		question += fmt.Sprintf(" (%d marked pods hidden by the filter are kept)", hidden)
+	}
+	var cmd tea.Cmd
+	m.confirm, cmd = confirm.New(question, name, m.ConfirmByName)
+	return cmd
+}
+
+// openExecPrompt Start typing a command to run in the marked pods
+func (m *Model) openExecPrompt() tea.Cmd {
+	m.bulk = m.Marked()
+	if len(m.bulk) == 0 {
+		return nil
+	}
+	m.prompt = execPrompt
+	m.execInput.SetValue("")
+	return m.execInput.Focus()
+}
+
+// runBulk Run an action on the pods in the background, the results arrive as BulkDoneMsg once
+// it is done, cancelled with esc or out of time
+func (m *Model) runBulk(action string, pds []v1.Pod, fn func(ctx context.Context, p v1.Pod) (string, error)) tea.Cmd {
+	m.running = true
+	m.results = nil
+	m.resultsAction = fmt.Sprintf("%s %d pods...", action, len(pds))
+	// The action stays on this cluster when another tab is shown
+	ctx, cancel := context.WithTimeout(kubernetes.Bind(context.Background()), bulkTimeout)
+	m.cancelBulk = cancel
+	return func() tea.Msg {
+		defer cancel()
+		results := kubernetes.RunBulk(ctx, pds, bulkConcurrency, fn)
+		return BulkDoneMsg{Action: action, Results: results}
+	}
+}
+
+func (m *Model) deleteMarked() tea.Cmd {
+	return m.runBulk("delete", m.bulk, func(ctx context.Context, p v1.Pod) (string, error) {
+		return "deleted", kubernetes.DeletePod(ctx, p.Namespace, p.Name)
+	})
+}
+
+func (m *Model) execMarked(command []string) tea.Cmd {
+	return m.runBulk(strings.Join(command, " "), m.bulk, func(ctx context.Context, p v1.Pod) (string, error) {
+		return kubernetes.ExecPod(ctx, p.Namespace, p.Name, command)
+	})
+}
+
+// resultsView Render one line per pod of the last bulk action
+func (m Model) resultsView() string {
+	if m.resultsAction == "" {
+		return ""
+	}
+	if m.running {
+		return statusStyle.Render(m.resultsAction + " (esc to cancel)")
+	}
+	failed := 0
+	lines := []string{}
+	for _, r := range m.results {
+		line := r.Pod
+		if m.Namespace == "" {
+			line = r.Namespace + "/" + r.Pod
+		}
+		if r.Err != nil {
+			failed++
+			line = line + ": " + errorStyle.Render(r.Err.Error())
+		} else if r.Output != "" {
+			out := strings.Split(r.Output, "\n")
+			line = line + ": " + out[len(out)-1]
+		}
+		lines = append(lines, line)
+	}
+	title := fmt.Sprintf("%s: %d ok, %d failed (esc to close)", m.resultsAction, len(m.results)-failed, failed)
+	if len(lines) > resultPanelLines {
+		lines = append(lines[:resultPanelLines], fmt.Sprintf("... %d more", len(lines)-resultPanelLines))
+	}
+	return panelStyle.Render(title + "\n" + strings.Join(lines, "\n"))
+}
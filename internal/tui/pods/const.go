@@ -1,9 +1,34 @@
 package pods
 
 import (
+	"time"
+
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
 )
 
+const maxColumnWidth = 50
+const columnPadding = 2
+
+// bulkConcurrency is how many pods a bulk action works on at once
+const bulkConcurrency = 5
+
+// bulkTimeout bounds a bulk action, a command that doesn't exit like tail -f ends with it
+const bulkTimeout = 2 * time.Minute
+
+// resultPanelLines is how many per-pod results the summary panel shows
+const resultPanelLines = 10
+
+const markSymbol = "*"
+
+// tableRows is how many pods the table shows when the view has the screen to itself
+const tableRows = 20
+
 var (
-	helpStyle = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
+	helpStyle   = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
+	statusStyle = lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Accent)
+	promptStyle = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	errorStyle  = lipgloss.NewStyle().Foreground(theme.Error)
+	panelStyle  = lipgloss.NewStyle().MarginLeft(2).Padding(0, 1).Border(lipgloss.RoundedBorder()).BorderForeground(theme.Accent)
 )
@@ -0,0 +1,41 @@
+package pods
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/export"
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+)
+
+// Export Get the rows the table shows, filtered and sorted as they are, with the context and namespace
+func (m Model) Export() export.Table {
+	name := kubernetes.InUse()
+	if name == "" {
+		name, _, _ = kubernetes.GetCurrent()
+	}
+	t := export.FromTable(m.Pods, name, m.Namespace)
+	// The sort arrow is for the screen, the column keeps its name
+	for i, c := range t.Columns {
+		t.Columns[i] = strings.TrimSuffix(c, m.sortArrow())
+	}
+	return t
+}
+
+// openExportPrompt Start typing where the rows go, a format to copy them or a file name
+func (m *Model) openExportPrompt() tea.Cmd {
+	m.prompt = exportPrompt
+	m.exportErr = nil
+	m.exportInput.SetValue("")
+	return m.exportInput.Focus()
+}
+
+// export Write the rows where the prompt says, the prompt stays open on a destination that doesn't work
+func (m *Model) export(destination string) {
+	notice, err := m.Export().Save(destination)
+	if err != nil {
+		m.exportErr = err
+		return
+	}
+	m.notice = notice
+	m.closePrompt()
+}
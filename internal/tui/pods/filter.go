@@ -0,0 +1,234 @@
+package pods
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sahilm/fuzzy"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"strings"
+)
+
+// prompt Which text input has the focus
+type prompt uint8
+
+const (
+	noPrompt prompt = iota
+	namePrompt
+	selectorPrompt
+	deletePrompt
+	execPrompt
+	exportPrompt
+)
+
+// SelectorMsg is sent when the label or field selector changed, the watch must follow
+type SelectorMsg struct{}
+
+// Typing Tell whether keys go to a text input
+func (m Model) Typing() bool {
+	return m.prompt != noPrompt
+}
+
+// CanClear Tell whether esc has something to cancel, close or clear: a bulk action, its results, the name filter or marks
+func (m Model) CanClear() bool {
+	return m.resultsAction != "" || m.NameFilter != "" || len(m.marked) > 0
+}
+
+// ListOptions Get the selectors to push down to list and watch calls
+func (m Model) ListOptions() metav1.ListOptions {
+	return metav1.ListOptions{LabelSelector: m.LabelSelector, FieldSelector: m.FieldSelector}
+}
+
+// FilterSummary Describe the active filters, empty when there are none
+func (m Model) FilterSummary() string {
+	var fs []string
+	if m.NameFilter != "" {
+		fs = append(fs, "/"+m.NameFilter)
+	}
+	if m.LabelSelector != "" {
+		fs = append(fs, "label:"+m.LabelSelector)
+	}
+	if m.FieldSelector != "" {
+		fs = append(fs, "field:"+m.FieldSelector)
+	}
+	return strings.Join(fs, " ")
+}
+
+func newInput(placeholder string) textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	return ti
+}
+
+// openNamePrompt Start typing a fuzzy name filter
+func (m *Model) openNamePrompt() tea.Cmd {
+	m.prompt = namePrompt
+	m.filterInput.SetValue(m.NameFilter)
+	m.filterInput.CursorEnd()
+	return m.filterInput.Focus()
+}
+
+// openSelectorPrompt Start typing a label selector, tab switches to a field selector
+func (m *Model) openSelectorPrompt() tea.Cmd {
+	m.prompt = selectorPrompt
+	m.selectorErr = nil
+	m.fieldMode = m.LabelSelector == "" && m.FieldSelector != ""
+	m.selectorInput.SetValue(m.currentSelector())
+	m.selectorInput.CursorEnd()
+	return m.selectorInput.Focus()
+}
+
+func (m Model) currentSelector() string {
+	if m.fieldMode {
+		return m.FieldSelector
+	}
+	return m.LabelSelector
+}
+
+func (m *Model) closePrompt() {
+	m.prompt = noPrompt
+	m.filterInput.Blur()
+	m.selectorInput.Blur()
+	m.execInput.Blur()
+	m.exportInput.Blur()
+}
+
+// updatePrompt Handle keys while a prompt is open
+func (m *Model) updatePrompt(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	switch m.prompt {
+	case namePrompt:
+		switch msg.String() {
+		case "enter":
+			m.closePrompt()
+		case "esc":
+			m.NameFilter = ""
+			m.closePrompt()
+			m.render()
+		default:
+			m.filterInput, cmd = m.filterInput.Update(msg)
+			m.NameFilter = strings.TrimSpace(m.filterInput.Value())
+			m.render()
+		}
+	case selectorPrompt:
+		switch msg.String() {
+		case "tab":
+			m.fieldMode = !m.fieldMode
+			m.selectorErr = nil
+			m.selectorInput.SetValue(m.currentSelector())
+			m.selectorInput.CursorEnd()
+		case "enter":
+			cmd = m.applySelector(strings.TrimSpace(m.selectorInput.Value()))
+		case "esc":
+			m.closePrompt()
+		default:
+			m.selectorInput, cmd = m.selectorInput.Update(msg)
+		}
+	case deletePrompt:
+		var ok bool
+		m.confirm, ok, cmd = m.confirm.Update(msg)
+		if ok {
+			cmd = m.deleteMarked()
+		}
+		if !m.confirm.Active() {
+			m.closePrompt()
+		}
+	case execPrompt:
+		switch msg.String() {
+		case "enter":
+			if command := strings.Fields(m.execInput.Value()); len(command) > 0 {
+				cmd = m.execMarked(command)
+			}
+			m.closePrompt()
+		case "esc":
+			m.closePrompt()
+		default:
+			m.execInput, cmd = m.execInput.Update(msg)
+		}
+	case exportPrompt:
+		switch msg.String() {
+		case "enter":
+			m.export(m.exportInput.Value())
+		case "esc":
+			m.closePrompt()
+		default:
+			m.exportErr = nil
+			m.exportInput, cmd = m.exportInput.Update(msg)
+		}
+	default:
+	}
+	return cmd
+}
+
+// applySelector Validate the typed selector and list the pods with it, one the server rejects keeps
+// the prompt open on the previous selector
+func (m *Model) applySelector(s string) tea.Cmd {
+	label, field := m.LabelSelector, m.FieldSelector
+	if m.fieldMode {
+		if _, err := fields.ParseSelector(s); err != nil {
+			m.selectorErr = err
+			return nil
+		}
+		m.FieldSelector = s
+	} else {
+		if _, err := labels.Parse(s); err != nil {
+			m.selectorErr = err
+			return nil
+		}
+		m.LabelSelector = s
+	}
+	if err := RefreshPods(m, true); err != nil {
+		m.selectorErr = err
+		m.LabelSelector, m.FieldSelector = label, field
+		_ = RefreshPods(m, true)
+		return nil
+	}
+	m.closePrompt()
+	return func() tea.Msg { return SelectorMsg{} }
+}
+
+// promptView Render the open prompt
+func (m Model) promptView() string {
+	switch m.prompt {
+	case namePrompt:
+		return promptStyle.Render("/") + m.filterInput.View()
+	case selectorPrompt:
+		label := "label selector (tab: field): "
+		if m.fieldMode {
+			label = "field selector (tab: label): "
+		}
+		s := promptStyle.Render(label) + m.selectorInput.View()
+		if m.selectorErr != nil {
+			s = s + "\n" + statusStyle.Render(m.selectorErr.Error())
+		}
+		return s
+	case deletePrompt:
+		return m.confirm.View()
+	case execPrompt:
+		return promptStyle.Render(fmt.Sprintf("exec in %d pods: ", len(m.bulk))) + m.execInput.View()
+	case exportPrompt:
+		s := promptStyle.Render(fmt.Sprintf("export %d rows to: ", len(m.Pods.Rows()))) + m.exportInput.View()
+		if m.exportErr != nil {
+			s = s + "\n" + statusStyle.Render(m.exportErr.Error())
+		}
+		return s
+	default:
+		return ""
+	}
+}
+
+// matchName Keep the pods whose name fuzzy matches the name filter
+func (m Model) matchName(pds []*v1.Pod) map[*v1.Pod]bool {
+	names := make([]string, len(pds))
+	for i, p := range pds {
+		names[i] = p.Name
+	}
+	matched := map[*v1.Pod]bool{}
+	for _, r := range fuzzy.Find(m.NameFilter, names) {
+		matched[pds[r.Index]] = true
+	}
+	return matched
+}
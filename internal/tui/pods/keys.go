@@ -3,33 +3,186 @@ package pods
 import "github.com/charmbracelet/bubbles/key"
 
 type KeyMap struct {
-	Context   key.Binding
-	Namespace key.Binding
-	Logs      key.Binding
+	Logs       key.Binding
+	Events     key.Binding
+	Nodes      key.Binding
+	Services   key.Binding
+	ConfigMaps key.Binding
+	Secrets    key.Binding
+	Jobs       key.Binding
+	Ingresses  key.Binding
+	Storage    key.Binding
+	Describe   key.Binding
+	Owners     key.Binding
+	Diagnose   key.Binding
+	Wide       key.Binding
+	Metrics    key.Binding
+	Sort       key.Binding
+	Filter     key.Binding
+	Selector   key.Binding
+	Mark       key.Binding
+	MarkAll    key.Binding
+	Invert     key.Binding
+	Delete     key.Binding
+	Exec       key.Binding
+	Export     key.Binding
+	Split      key.Binding
+	Rotate     key.Binding
+	Focus      key.Binding
+	Grow       key.Binding
+	Shrink     key.Binding
+	Help       key.Binding
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Namespace, k.Context, k.Logs}
+	return append([]key.Binding{Palette, k.Logs, k.Describe, k.Filter, k.Help}, Plugins...)
 
 }
 
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Namespace, k.Context, k.Logs},
+		{Palette, k.Events, k.Nodes},
+		{k.Services, k.Ingresses, k.ConfigMaps, k.Secrets},
+		{k.Jobs, k.Storage},
+		{k.Logs, k.Describe, k.Diagnose, k.Owners, k.Wide, k.Metrics},
+		{k.Sort, k.Filter, k.Selector},
+		{k.Mark, k.MarkAll, k.Invert, k.Delete, k.Exec},
+		{k.Export},
+		{k.Split, k.Rotate, k.Focus, k.Grow, k.Shrink},
+		{k.Help},
 	}
 }
 
+// Palette opens the command line from every view, it is shown here as the pods are the home view.
+// LoadConfig sets it to the configured global key.
+var Palette = key.NewBinding(
+	key.WithKeys(":"),
+	key.WithHelp(":", "command"),
+)
+
 var keys = KeyMap{
-	Context: key.NewBinding(
-		key.WithKeys("c"),
-		key.WithHelp("c", "context"),
-	),
-	Namespace: key.NewBinding(
-		key.WithKeys("n"),
-		key.WithHelp("n", "namespace"),
-	),
 	Logs: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "logs"),
 	),
+	Events: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "events"),
+	),
+	Nodes: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "nodes"),
+	),
+	Services: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "services"),
+	),
+	ConfigMaps: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "configmaps"),
+	),
+	Secrets: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "secrets"),
+	),
+	Jobs: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "jobs"),
+	),
+	Ingresses: key.NewBinding(
+		key.WithKeys("I"),
+		key.WithHelp("I", "ingresses"),
+	),
+	Storage: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "storage"),
+	),
+	Describe: key.NewBinding(
+		key.WithKeys("d"),
+		key.WithHelp("d", "describe"),
+	),
+	Diagnose: key.NewBinding(
+		key.WithKeys("W"),
+		key.WithHelp("W", "why failing/pending"),
+	),
+	Owners: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "owners/xray"),
+	),
+	Wide: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "wide"),
+	),
+	Metrics: key.NewBinding(
+		key.WithKeys("u"),
+		key.WithHelp("u", "cpu/mem"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("alt+n", "alt+s", "alt+r", "alt+a", "alt+o", "alt+c", "alt+m"),
+		key.WithHelp("alt+n/s/r/a/o/c/m", "sort name/status/restarts/age/node/cpu/mem"),
+	),
+	Filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter"),
+	),
+	Selector: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "selector"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "mark"),
+	),
+	MarkAll: key.NewBinding(
+		key.WithKeys("ctrl+a"),
+		key.WithHelp("ctrl+a", "mark all"),
+	),
+	Invert: key.NewBinding(
+		key.WithKeys("*"),
+		key.WithHelp("*", "invert marks"),
+	),
+	Delete: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "delete marked"),
+	),
+	Exec: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "exec in marked"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "export csv/json/md"),
+	),
+	Split: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "log preview"),
+	),
+	Rotate: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "preview below/beside"),
+	),
+	Focus: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "switch pane"),
+	),
+	Grow: key.NewBinding(
+		key.WithKeys("+"),
+		key.WithHelp("+", "grow pane"),
+	),
+	Shrink: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "shrink pane"),
+	),
+	Help: key.NewBinding(
+		key.WithKeys("?"),
+		key.WithHelp("?", "more"),
+	),
+}
+
+// Plugins are the user commands that apply to the view, set by LoadConfig
+var Plugins []key.Binding
+
+// Bindings Get the key map so the user configuration can remap it
+func Bindings() *KeyMap {
+	return &keys
 }
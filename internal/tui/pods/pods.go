@@ -2,21 +2,80 @@ package pods
 
 import (
 	"context"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
 	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/confirm"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/usage"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
+	"strings"
+	"time"
 )
 
 type Model struct {
-	Namespace string
-	Pods      table.Model
-	Help      help.Model
+	Namespace     string
+	LabelSelector string
+	FieldSelector string
+	Pods          table.Model
+	Help          help.Model
+	Wide          bool
+	Metrics       bool
+	// ConfirmByName makes deletions ask for the pod name, it follows the context profile
+	ConfirmByName bool
+	SortBy        SortKey
+	SortDesc      bool
+	NameFilter    string
+	table         *metav1.Table
+	items         []*v1.Pod
+	usage         map[string]v1.ResourceList
+	metricsErr    error
+	metricsTick   uint64
+	prompt        prompt
+	filterInput   textinput.Model
+	selectorInput textinput.Model
+	fieldMode     bool
+	selectorErr   error
+	execInput     textinput.Model
+	exportInput   textinput.Model
+	exportErr     error
+	// notice tells where the last export went until the next key, status why the pods could not
+	// be listed until they are
+	notice        string
+	status        string
+	marked        map[types.UID]bool
+	bulk          []v1.Pod
+	confirm       confirm.Model
+	running       bool
+	cancelBulk    context.CancelFunc
+	results       []kubernetes.BulkResult
+	resultsAction string
 }
 type ChangeMsg watch.Event
 
+// MetricsTickMsg asks to poll pod metrics again, only the table whose polling sent it takes it,
+// on the cluster the polling started on
+type MetricsTickMsg struct {
+	seq uint64
+	ctx context.Context
+}
+
+// metricsSeq numbers the pollings of every pod table, a tick of a polling turned off and on
+// again is dropped instead of running alongside the new one
+var metricsSeq uint64
+
+func tickMetrics(seq uint64) tea.Cmd {
+	c := kubernetes.Bind(context.Background())
+	return tea.Tick(config.Current.Refresh.Metrics.Duration, func(time.Time) tea.Msg { return MetricsTickMsg{seq: seq, ctx: c} })
+}
+
 func (m Model) Init() tea.Cmd {
 	return nil
 }
@@ -29,76 +88,299 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Pods.SetWidth(msg.Width)
 
 	case tea.KeyMsg:
+		if m.Typing() {
+			cmd = m.updatePrompt(msg)
+			return m, cmd
+		}
+		m.notice = ""
 		switch keypress := msg.String(); keypress {
 		case "enter":
+		case "/":
+			cmd = m.openNamePrompt()
+		case "L":
+			cmd = m.openSelectorPrompt()
+		case "esc":
+			switch {
+			case m.running:
+				m.cancelBulk()
+			case m.resultsAction != "" && !m.running:
+				m.resultsAction = ""
+				m.results = nil
+			case m.NameFilter != "":
+				m.NameFilter = ""
+				m.render()
+			case len(m.marked) > 0:
+				m.marked = map[types.UID]bool{}
+				m.render()
+			}
+		case " ":
+			m.toggleMark()
+		case "ctrl+a":
+			m.markAll()
+		case "*":
+			m.invertMarks()
+		case "D":
+			if !m.running {
+				cmd = m.openDeletePrompt()
+			}
+		case "x":
+			if !m.running {
+				cmd = m.openExecPrompt()
+			}
+		case "E":
+			cmd = m.openExportPrompt()
+		case "w":
+			m.Wide = !m.Wide
+			m.render()
+		case "?":
+			m.Help.ShowAll = !m.Help.ShowAll
+		case "u":
+			m.Metrics = !m.Metrics
+			if m.Metrics {
+				metricsSeq++
+				m.metricsTick = metricsSeq
+				refreshMetrics(context.Background(), &m)
+				cmd = tickMetrics(m.metricsTick)
+			}
+			m.render()
 		default:
+			if k, ok := sortKeys[keypress]; ok {
+				m.setSort(k)
+				m.render()
+				break
+			}
 			m.Pods, cmd = m.Pods.Update(msg)
 		}
 	case ChangeMsg:
 		RefreshPods(&m, false)
+	case BulkDoneMsg:
+		m.running = false
+		m.cancelBulk = nil
+		m.results = msg.Results
+		m.resultsAction = msg.Action
+		RefreshPods(&m, false)
+	case MetricsTickMsg:
+		if m.Metrics && msg.seq == m.metricsTick {
+			refreshMetrics(msg.ctx, &m)
+			m.render()
+			cmd = tickMetrics(m.metricsTick)
+		}
 	}
 	return m, cmd
 
 }
 
 func (m Model) View() string {
-	return m.Pods.View() + helpStyle.Render(m.Help.View(keys))
+	s := m.Pods.View()
+	if p := m.promptView(); p != "" {
+		s = s + "\n" + p
+	}
+	if m.notice != "" {
+		s = s + "\n" + statusStyle.Render(m.notice)
+	}
+	if m.status != "" {
+		s = s + "\n" + statusStyle.Render(m.status)
+	}
+	if r := m.resultsView(); r != "" {
+		s = s + "\n" + r
+	}
+	if m.Metrics && m.metricsErr != nil {
+		s = s + "\n" + statusStyle.Render("metrics: "+m.metricsErr.Error())
+	}
+	return s + helpStyle.Render(m.Help.View(keys))
+}
+
+// SetHeight Fit the view in a number of lines, zero gives the table its usual height
+func (m *Model) SetHeight(h int) {
+	rows := tableRows
+	if h > 0 {
+		rows = max(h-(lipgloss.Height(m.View())-m.Pods.Height()), 1)
+	}
+	header := lipgloss.Height(m.Pods.View()) - m.Pods.Height()
+	m.Pods.SetHeight(rows + header)
+}
+
+// refreshMetrics Poll pod usage, the columns show n/a when metrics are unavailable
+func refreshMetrics(c context.Context, m *Model) {
+	ctx, cancelFunc := context.WithCancel(c)
+	defer cancelFunc()
+	m.usage, m.metricsErr = kubernetes.GetPodUsage(ctx, m.Namespace, metav1.ListOptions{LabelSelector: m.LabelSelector})
 }
 
-func RefreshPods(m *Model, goTop bool) {
-	var rows []table.Row
+// RefreshPods List the pods again, a failed list empties the table and shows why in the status
+func RefreshPods(m *Model, goTop bool) error {
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	defer cancelFunc()
-	pds, err := kubernetes.GetPods(ctx, m.Namespace)
+	t, err := kubernetes.GetPodTable(ctx, m.Namespace, m.ListOptions())
+	m.status = ""
 	if err != nil {
-		panic(err)
-	}
-	for _, p := range pds {
-		row := table.Row{
-			p.Name,
-			kubernetes.ColumnHelperReady(p.Status.ContainerStatuses),
-			kubernetes.ColumnHelperStatus(p.Status),
-			kubernetes.ColumnHelperRestarts(p.Status.ContainerStatuses),
-			kubernetes.ColumnHelperAge(p.CreationTimestamp),
+		m.status = err.Error()
+		if m.table != nil {
+			// Rows of another selector or cluster would pass for the ones asked for
+			t = &metav1.Table{ColumnDefinitions: m.table.ColumnDefinitions}
 		}
-		rows = append(rows, row)
 	}
-	m.Pods.SetRows(rows)
+	m.table = t
+	m.render()
 	if goTop {
 		m.Pods.GotoTop()
 	}
+	return err
 }
 
-func New(namespace string) Model {
-	columns := []table.Column{
-		{Title: "NAME", Width: 50},
-		{Title: "READY", Width: 5},
-		{Title: "STATUS", Width: 10},
-		{Title: "RESTARTS", Width: 10},
-		{Title: "AGE", Width: 5},
+// SelectedPod Get the pod under the cursor
+func (m Model) SelectedPod() (*v1.Pod, bool) {
+	c := m.Pods.Cursor()
+	if c < 0 || c >= len(m.items) {
+		return nil, false
+	}
+	return m.items[c], true
+}
+
+// Select Move the cursor to the named pod
+func (m *Model) Select(name string) {
+	for i, p := range m.items {
+		if p.Name == name {
+			m.Pods.SetCursor(i)
+			return
+		}
+	}
+}
+
+// render Build the table columns and rows from the last fetched table
+func (m *Model) render() {
+	if m.table == nil {
+		return
+	}
+	var (
+		idx     []int
+		columns []table.Column
+		rows    []table.Row
+	)
+	selected, hadSelection := m.SelectedPod()
+	m.items = nil
+	m.pruneMarks()
+	showMarks := len(m.marked) > 0
+	if showMarks {
+		columns = append(columns, table.Column{Title: "", Width: len(markSymbol)})
+	}
+	allNamespaces := m.Namespace == ""
+	if allNamespaces {
+		columns = append(columns, table.Column{Title: "NAMESPACE", Width: len("NAMESPACE")})
+	}
+	for i, c := range m.table.ColumnDefinitions {
+		if c.Priority > 0 && !m.Wide {
+			continue
+		}
+		idx = append(idx, i)
+		columns = append(columns, table.Column{Title: strings.ToUpper(c.Name), Width: len(c.Name)})
+	}
+	// Sort a copy, the fetched table may be shared with older copies of the model
+	tableRows := append([]metav1.TableRow(nil), m.table.Rows...)
+	m.sortRows(tableRows)
+	var matched map[*v1.Pod]bool
+	if m.NameFilter != "" {
+		var pds []*v1.Pod
+		for _, r := range tableRows {
+			if p, ok := kubernetes.TablePod(r); ok {
+				pds = append(pds, p)
+			}
+		}
+		matched = m.matchName(pds)
+	}
+	for _, r := range tableRows {
+		p, ok := kubernetes.TablePod(r)
+		if !ok || (matched != nil && !matched[p]) {
+			continue
+		}
+		var row table.Row
+		if showMarks {
+			mark := ""
+			if m.marked[p.UID] {
+				mark = markSymbol
+			}
+			row = append(row, mark)
+		}
+		if allNamespaces {
+			row = append(row, p.Namespace)
+		}
+		for _, i := range idx {
+			cell := ""
+			if i < len(r.Cells) {
+				cell = kubernetes.TableCell(r.Cells[i])
+			}
+			row = append(row, cell)
+		}
+		for j := range row {
+			columns[j].Width = min(max(columns[j].Width, len(row[j])), config.ColumnWidth(maxColumnWidth))
+		}
+		rows = append(rows, row)
+		m.items = append(m.items, p)
+	}
+	for j := range columns {
+		columns[j].Width += columnPadding
+	}
+	if m.Metrics {
+		m.addMetricsColumns(&columns, rows)
 	}
+	m.markSortColumn(columns)
+	// Drop the old rows first, they may be wider than the new columns
+	m.Pods.SetRows(nil)
+	m.Pods.SetColumns(columns)
+	m.Pods.SetRows(rows)
+
+	// Keep the cursor on the same pod when rows move around
+	if hadSelection {
+		for i, p := range m.items {
+			if p.UID == selected.UID {
+				m.Pods.SetCursor(i)
+				break
+			}
+		}
+	}
+}
+
+// NewForNode Build a pod table of the pods scheduled on a node
+func NewForNode(node string) Model {
+	return newModel("", "", kubernetes.NodePodsSelector(node))
+}
 
+// NewForSelector Build a pod table of the pods matching a label selector
+func NewForSelector(namespace string, selector string) Model {
+	return newModel(namespace, selector, "")
+}
+
+// addMetricsColumns Append CPU and MEM usage to every row
+func (m *Model) addMetricsColumns(columns *[]table.Column, rows []table.Row) {
+	*columns = usage.AddColumns(*columns, rows, columnPadding, []string{"CPU %REQ/%LIM", "MEM %REQ/%LIM"}, func(i int) []string {
+		p := m.items[i]
+		used := m.usage[p.Namespace+"/"+p.Name]
+		reqs, limits := kubernetes.PodRequests(*p), kubernetes.PodLimits(*p)
+		return []string{usage.Cell(v1.ResourceCPU, used, reqs, limits), usage.Cell(v1.ResourceMemory, used, reqs, limits)}
+	})
+}
+
+func New(namespace string) Model {
+	return newModel(namespace, "", "")
+}
+
+func newModel(namespace string, labelSelector string, fieldSelector string) Model {
 	t := table.New(
-		table.WithColumns(columns),
 		table.WithFocused(true),
 	)
-
-	s := table.DefaultStyles()
-	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("#FF7900")).
-		BorderBottom(true).
-		Bold(false)
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("#FF7900")).
-		Background(lipgloss.Color("#000")).
-		Bold(false)
-	t.SetStyles(s)
+	t.SetStyles(theme.TableStyles())
 
 	m := Model{
-		Namespace: namespace,
-		Pods:      t,
-		Help:      help.New(),
+		Namespace:     namespace,
+		LabelSelector: labelSelector,
+		FieldSelector: fieldSelector,
+		Pods:          t,
+		Help:          help.New(),
+		filterInput:   newInput("pod name"),
+		selectorInput: newInput("app=api,tier!=cache"),
+		execInput:     newInput("command"),
+		exportInput:   newInput("csv, json or md to copy, or a file like pods.csv"),
+		marked:        map[types.UID]bool{},
 	}
 	RefreshPods(&m, true)
 	return m
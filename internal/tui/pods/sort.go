@@ -0,0 +1,140 @@
+package pods
+
+import (
+	"cmp"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/charmbracelet/bubbles/table"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"strings"
+)
+
+// SortKey Column the pods are sorted by
+type SortKey uint8
+
+const (
+	SortNone SortKey = iota
+	SortName
+	SortStatus
+	SortRestarts
+	SortAge
+	SortNode
+	SortCPU
+	SortMemory
+)
+
+// sortKeys maps the sort keybindings to their column
+var sortKeys = map[string]SortKey{
+	"alt+n": SortName,
+	"alt+s": SortStatus,
+	"alt+r": SortRestarts,
+	"alt+a": SortAge,
+	"alt+o": SortNode,
+	"alt+c": SortCPU,
+	"alt+m": SortMemory,
+}
+
+// column Get the title of the column showing the sort key
+func (k SortKey) column() string {
+	switch k {
+	case SortName:
+		return "NAME"
+	case SortStatus:
+		return "STATUS"
+	case SortRestarts:
+		return "RESTARTS"
+	case SortAge:
+		return "AGE"
+	case SortNode:
+		return "NODE"
+	case SortCPU:
+		return "CPU"
+	case SortMemory:
+		return "MEM"
+	default:
+		return ""
+	}
+}
+
+// setSort Sort by a column, choosing it again flips the direction
+func (m *Model) setSort(k SortKey) {
+	if m.SortBy == k {
+		m.SortDesc = !m.SortDesc
+		return
+	}
+	m.SortBy = k
+	m.SortDesc = false
+}
+
+// sortRows Order the table rows by the typed value of the sort column
+func (m Model) sortRows(rows []metav1.TableRow) {
+	if m.SortBy == SortNone {
+		return
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, aok := kubernetes.TablePod(rows[i])
+		b, bok := kubernetes.TablePod(rows[j])
+		if !aok || !bok {
+			return false
+		}
+		c := m.compare(a, b)
+		if m.SortDesc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// compare Compare two pods on the sort column, ties are broken by name
+func (m Model) compare(a *v1.Pod, b *v1.Pod) int {
+	c := 0
+	switch m.SortBy {
+	case SortStatus:
+		c = strings.Compare(kubernetes.PodStatusReason(*a), kubernetes.PodStatusReason(*b))
+	case SortRestarts:
+		c = kubernetes.RestartCount(a.Status.ContainerStatuses) - kubernetes.RestartCount(b.Status.ContainerStatuses)
+	case SortAge:
+		// Older pods have a larger age
+		c = b.CreationTimestamp.Time.Compare(a.CreationTimestamp.Time)
+	case SortNode:
+		c = strings.Compare(a.Spec.NodeName, b.Spec.NodeName)
+	case SortCPU:
+		c = cmp.Compare(m.usageOf(a, v1.ResourceCPU), m.usageOf(b, v1.ResourceCPU))
+	case SortMemory:
+		c = cmp.Compare(m.usageOf(a, v1.ResourceMemory), m.usageOf(b, v1.ResourceMemory))
+	}
+	if c == 0 {
+		c = strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	}
+	return c
+}
+
+// usageOf Get the usage of a pod in milli units, pods without metrics count as zero
+func (m Model) usageOf(p *v1.Pod, name v1.ResourceName) int64 {
+	q := m.usage[p.Namespace+"/"+p.Name][name]
+	return q.MilliValue()
+}
+
+// sortArrow Get the arrow marking the sort direction
+func (m Model) sortArrow() string {
+	if m.SortDesc {
+		return " ▼"
+	}
+	return " ▲"
+}
+
+// markSortColumn Put the sort arrow on the header of the sort column
+func (m Model) markSortColumn(columns []table.Column) {
+	title := m.SortBy.column()
+	if title == "" {
+		return
+	}
+	for i, c := range columns {
+		if c.Title == title || strings.HasPrefix(c.Title, title+" ") {
+			columns[i].Title = c.Title + m.sortArrow()
+			columns[i].Width = columns[i].Width + len(m.sortArrow())
+			return
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package resources
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const maxColumnWidth = 50
+const columnPadding = 2
+
+var (
+	titleStyle  = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	statusStyle = lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Accent)
+	helpStyle   = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
+)
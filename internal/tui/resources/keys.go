@@ -0,0 +1,34 @@
+package resources
+
+import "github.com/charmbracelet/bubbles/key"
+
+type KeyMap struct {
+	Wide    key.Binding
+	Refresh key.Binding
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Wide, k.Refresh}
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Wide, k.Refresh},
+	}
+}
+
+var keys = KeyMap{
+	Wide: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "wide"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
+}
+
+// Bindings Get the key map so the user configuration can remap it
+func Bindings() *KeyMap {
+	return &keys
+}
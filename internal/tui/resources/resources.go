@@ -0,0 +1,161 @@
+// Package resources lists any API resource, custom ones included, with the columns the server
+// prints for kubectl get: the additional printer columns of a CRD and the wide ones with w.
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+)
+
+// Model lists the objects of a resource as the server prints them
+type Model struct {
+	Resource kubernetes.Resource
+	// Namespace is empty for every namespace and for cluster resources
+	Namespace string
+	Objects   table.Model
+	Help      help.Model
+	Wide      bool
+	table     *metav1.Table
+	status    string
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Help.Width = msg.Width
+		m.Objects.SetWidth(msg.Width)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "w":
+			m.Wide = !m.Wide
+			m.render()
+		case "r":
+			RefreshResources(&m)
+		case "?":
+			m.Help.ShowAll = !m.Help.ShowAll
+		default:
+			m.Objects, cmd = m.Objects.Update(msg)
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	ns := m.Namespace
+	if ns == "" {
+		ns = "all namespaces"
+	}
+	if !m.Resource.Namespaced {
+		ns = "cluster"
+	}
+	b.WriteString("\n" + titleStyle.Render(fmt.Sprintf("%s (%s)", m.Resource.Name, ns)) + "\n\n")
+	b.WriteString(m.Objects.View() + "\n")
+	if m.status != "" {
+		b.WriteString(statusStyle.Render(m.status) + "\n")
+	}
+	b.WriteString(helpStyle.Render(m.Help.View(keys)))
+	return b.String()
+}
+
+// RefreshResources List the objects again, an error shows in the status line
+func RefreshResources(m *Model) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	ns := m.Namespace
+	if !m.Resource.Namespaced {
+		ns = ""
+	}
+	t, err := kubernetes.GetTable(ctx, m.Resource, ns, metav1.ListOptions{})
+	switch {
+	case errors.Is(err, kubernetes.ErrTableUnsupported):
+		m.status = fmt.Sprintf("the server can't print %s as a table", m.Resource.Name)
+		return
+	case err != nil:
+		m.status = err.Error()
+		return
+	}
+	m.status = ""
+	m.table = t
+	m.render()
+}
+
+// render Build the columns and rows of the last table, wide columns only in wide mode
+func (m *Model) render() {
+	if m.table == nil {
+		return
+	}
+	var (
+		idx     []int
+		columns []table.Column
+		rows    []table.Row
+	)
+	// The server prints no namespace column, the objects of the rows carry it
+	allNamespaces := m.Resource.Namespaced && m.Namespace == ""
+	if allNamespaces {
+		columns = append(columns, table.Column{Title: "NAMESPACE", Width: len("NAMESPACE")})
+	}
+	for i, c := range m.table.ColumnDefinitions {
+		if c.Priority > 0 && !m.Wide {
+			continue
+		}
+		idx = append(idx, i)
+		columns = append(columns, table.Column{Title: strings.ToUpper(c.Name), Width: len(c.Name)})
+	}
+	for _, r := range m.table.Rows {
+		var row table.Row
+		if allNamespaces {
+			o, _ := kubernetes.TableObject(r)
+			row = append(row, o.Namespace)
+		}
+		for _, i := range idx {
+			cell := ""
+			if i < len(r.Cells) {
+				cell = kubernetes.TableCell(r.Cells[i])
+			}
+			row = append(row, cell)
+		}
+		for j := range row {
+			columns[j].Width = min(max(columns[j].Width, len(row[j])), config.ColumnWidth(maxColumnWidth))
+		}
+		rows = append(rows, row)
+	}
+	for j := range columns {
+		columns[j].Width += columnPadding
+	}
+	m.Objects.SetRows(nil)
+	m.Objects.SetColumns(columns)
+	m.Objects.SetRows(rows)
+}
+
+// New List the objects of a resource found with kubernetes.FindResource
+func New(r kubernetes.Resource, namespace string) Model {
+	t := table.New(
+		table.WithFocused(true),
+	)
+	t.SetStyles(theme.TableStyles())
+
+	m := Model{
+		Resource:  r,
+		Namespace: namespace,
+		Objects:   t,
+		Help:      help.New(),
+	}
+	RefreshResources(&m)
+	return m
+}
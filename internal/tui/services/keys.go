@@ -0,0 +1,38 @@
+package services
+
+import "github.com/charmbracelet/bubbles/key"
+
+type KeyMap struct {
+	Pods    key.Binding
+	Refresh key.Binding
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return append([]key.Binding{k.Pods, k.Refresh}, Plugins...)
+
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Pods, k.Refresh},
+	}
+}
+
+var keys = KeyMap{
+	Pods: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "pods"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
+}
+
+// Plugins are the user commands that apply to the view, set by LoadConfig
+var Plugins []key.Binding
+
+// Bindings Get the key map so the user configuration can remap it
+func Bindings() *KeyMap {
+	return &keys
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/pods"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/usage"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	"strconv"
+	"strings"
+)
+
+type Model struct {
+	Namespace string
+	Services  table.Model
+	Help      help.Model
+	Pods      pods.Model
+	ShowPods  bool
+	// ConfirmByName makes pod deletions ask for the name, it follows the context profile
+	ConfirmByName bool
+	podsService   string
+	items         []v1.Service
+	// status tells why the last listing failed, notice what the last action did until the next key
+	status string
+	notice string
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Help.Width = msg.Width
+		m.Services.SetWidth(msg.Width)
+
+	case tea.KeyMsg:
+		if m.ShowPods {
+			var podModel tea.Model
+			podModel, cmd = m.Pods.Update(msg)
+			if pod, ok := podModel.(pods.Model); ok {
+				m.Pods = pod
+			}
+			return m, cmd
+		}
+		m.notice = ""
+		switch keypress := msg.String(); keypress {
+		case "enter":
+			svc, ok := m.SelectedService()
+			if !ok {
+				break
+			}
+			sel := kubernetes.ServicePodsSelector(svc)
+			if sel == "" {
+				m.notice = fmt.Sprintf("service/%s has no selector", svc.Name)
+				break
+			}
+			m.Pods = pods.NewForSelector(svc.Namespace, sel)
+			m.Pods.ConfirmByName = m.ConfirmByName
+			m.podsService = svc.Name
+			m.ShowPods = true
+		case "r":
+			RefreshServices(&m)
+		default:
+			m.Services, cmd = m.Services.Update(msg)
+		}
+	case pods.MetricsTickMsg:
+		if m.ShowPods {
+			var podModel tea.Model
+			podModel, cmd = m.Pods.Update(msg)
+			if pod, ok := podModel.(pods.Model); ok {
+				m.Pods = pod
+			}
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if m.ShowPods {
+		return "\n" + titleStyle.Render("Pods behind service/"+m.podsService) + "\n\n" + m.Pods.View()
+	}
+	var b strings.Builder
+	b.WriteString("\n" + titleStyle.Render(fmt.Sprintf("Services (%s)", m.Namespace)) + "\n\n")
+	b.WriteString(m.Services.View() + "\n")
+	for _, s := range []string{m.notice, m.status} {
+		if s != "" {
+			b.WriteString(statusStyle.Render(s) + "\n")
+		}
+	}
+	b.WriteString(helpStyle.Render(m.Help.View(keys)))
+	return b.String()
+}
+
+// Crumb Get the breadcrumb of the drill-down, empty when showing the services
+func (m Model) Crumb() string {
+	if m.ShowPods {
+		return "svc/" + m.podsService
+	}
+	return ""
+}
+
+// Typing Tell whether keys go to a prompt of the pods drill-down
+func (m Model) Typing() bool {
+	return m.ShowPods && m.Pods.Typing()
+}
+
+// SelectedService Get the service under the cursor
+func (m Model) SelectedService() (v1.Service, bool) {
+	c := m.Services.Cursor()
+	if c < 0 || c >= len(m.items) {
+		return v1.Service{}, false
+	}
+	return m.items[c], true
+}
+
+// Select Move the cursor to the named service
+func (m *Model) Select(name string) {
+	for i, s := range m.items {
+		if s.Name == name {
+			m.Services.SetCursor(i)
+			return
+		}
+	}
+}
+
+func RefreshServices(m *Model) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	m.status = ""
+	svcs, err := kubernetes.GetServices(ctx, m.Namespace)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	slices, err := kubernetes.GetEndpointSlices(ctx, m.Namespace)
+	if err != nil {
+		m.status = "endpoints: " + err.Error()
+	}
+
+	columns := []table.Column{
+		{Title: "NAME"}, {Title: "TYPE"}, {Title: "CLUSTER-IP"}, {Title: "EXTERNAL-IP"},
+		{Title: "PORTS"}, {Title: "READY"}, {Title: "NOT READY"}, {Title: "SELECTOR"},
+	}
+	var rows []table.Row
+	for _, svc := range svcs {
+		ready, notReady := usage.Unavailable, usage.Unavailable
+		if slices != nil {
+			r, nr := kubernetes.EndpointCounts(slices[svc.Name])
+			ready, notReady = strconv.Itoa(r), strconv.Itoa(nr)
+		}
+		rows = append(rows, table.Row{
+			svc.Name,
+			string(svc.Spec.Type),
+			svc.Spec.ClusterIP,
+			kubernetes.ColumnHelperExternalIPs(svc),
+			kubernetes.ColumnHelperPorts(svc),
+			ready,
+			notReady,
+			kubernetes.ColumnHelperSelector(svc),
+		})
+	}
+	for j := range columns {
+		columns[j].Width = len(columns[j].Title)
+		for _, r := range rows {
+			columns[j].Width = min(max(columns[j].Width, len(r[j])), config.ColumnWidth(maxColumnWidth))
+		}
+		columns[j].Width += columnPadding
+	}
+
+	m.items = svcs
+	m.Services.SetRows(nil)
+	m.Services.SetColumns(columns)
+	m.Services.SetRows(rows)
+}
+
+func New(namespace string) Model {
+	t := table.New(
+		table.WithFocused(true),
+	)
+
+	t.SetStyles(theme.TableStyles())
+
+	m := Model{
+		Namespace: namespace,
+		Services:  t,
+		Help:      help.New(),
+	}
+	RefreshServices(&m)
+	return m
+}
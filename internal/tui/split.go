@@ -0,0 +1,165 @@
+package tui
+
+import (
+	ctx "context"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/layout"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/logs"
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+	"time"
+)
+
+// previewDelay is how long the cursor rests on a pod before its logs stream, scrolling past pods opens none
+const previewDelay = 300 * time.Millisecond
+
+// previewMsg is sent once the cursor rested, seq tells whether it moved since
+type previewMsg struct {
+	seq int
+}
+
+// previewErrMsg is sent when the logs of a previewed pod can't be streamed
+type previewErrMsg struct {
+	ref kubernetes.ObjectRef
+	err error
+}
+
+// logPane is the live log preview of the pod under the cursor, below or beside the pod table
+type logPane struct {
+	on    bool
+	split layout.Split
+	logs  logs.Model
+	// streaming is the pod the logs come from, want the pod under the cursor and container its default one
+	streaming kubernetes.ObjectRef
+	want      kubernetes.ObjectRef
+	container string
+	seq       int
+	// err is why the logs of the streaming pod don't come
+	err error
+}
+
+// toggleSplit Show or hide the log preview
+func (m *Model) toggleSplit() tea.Cmd {
+	m.pane.on = !m.pane.on
+	if !m.pane.on {
+		m.stopPreview()
+		m.pod.SetHeight(0)
+		return nil
+	}
+	m.pane.split.Focus = 0
+	m.resizePanes()
+	return m.followCursor()
+}
+
+// stopPreview Close the stream of the preview, the next cursor move opens another one
+func (m *Model) stopPreview() {
+	if m.pane.logs.Ctx != nil {
+		m.pane.logs.Stop()
+	}
+	m.pane.logs = logs.Model{}
+	m.pane.streaming = kubernetes.ObjectRef{}
+	m.pane.want = kubernetes.ObjectRef{}
+	m.pane.container = ""
+	m.pane.err = nil
+}
+
+// followCursor Wait for the cursor to rest on a pod before previewing its logs
+func (m *Model) followCursor() tea.Cmd {
+	var want kubernetes.ObjectRef
+	var container string
+	if p, ok := m.pod.SelectedPod(); ok {
+		want = kubernetes.ObjectRef{Kind: "Pod", Namespace: p.Namespace, Name: p.Name}
+		container = kubernetes.DefaultContainer(*p)
+	}
+	if want == m.pane.want {
+		return nil
+	}
+	m.pane.want, m.pane.container = want, container
+	m.pane.seq++
+	seq := m.pane.seq
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg { return previewMsg{seq: seq} })
+}
+
+// startPreview Stream the logs of the pod the cursor rested on, cancelling the previous stream
+func (m *Model) startPreview() tea.Cmd {
+	if m.pane.want == m.pane.streaming && m.pane.logs.Ctx != nil {
+		return nil
+	}
+	if m.pane.logs.Ctx != nil {
+		m.pane.logs.Stop()
+	}
+	m.pane.streaming = m.pane.want
+	m.pane.err = nil
+	_, _, w, h := m.paneSizes()
+	m.pane.logs = logs.NewPreview(kubernetes.Bind(ctx.Background()), w, h-1)
+	if m.pane.streaming.Name == "" {
+		return nil
+	}
+	c, out, ref, container := m.pane.logs.Ctx, m.pane.logs.LogChan, m.pane.streaming, m.pane.container
+	// Pods with several containers need one named, the default one is what kubectl shows
+	stream := func() tea.Msg {
+		err := kubernetes.GetPodLogs(c, ref.Namespace, ref.Name, container, config.Current.Logs.Tail, out)
+		if err != nil && c.Err() == nil {
+			return previewErrMsg{ref: ref, err: err}
+		}
+		return nil
+	}
+	return tea.Batch(stream, logs.WatchLogs(m.pane.logs))
+}
+
+// paneArea Get the size left to the panes under the banner and the header
+func (m Model) paneArea() (int, int) {
+	used := strings.Count(m.banner()+m.header(), "\n")
+	return m.width, max(m.height-used, 2)
+}
+
+func (m Model) paneSizes() (int, int, int, int) {
+	w, h := m.paneArea()
+	return m.pane.split.Sizes(w, h)
+}
+
+// resizePanes Fit the pod table and the preview in their panes
+func (m *Model) resizePanes() {
+	if !m.pane.on {
+		return
+	}
+	_, h1, w2, h2 := m.paneSizes()
+	m.pod.SetHeight(h1)
+	if m.pane.logs.Ctx != nil {
+		l, _ := m.pane.logs.Update(tea.WindowSizeMsg{Width: w2, Height: h2 - 1})
+		if lm, ok := l.(logs.Model); ok {
+			m.pane.logs = lm
+		}
+	}
+}
+
+// updatePreview Scroll the preview while it has the focus, esc gives the focus back to the table
+func (m *Model) updatePreview(msg tea.KeyMsg, cmd *tea.Cmd) {
+	if msg.String() == "esc" {
+		m.pane.split.Focus = 0
+		return
+	}
+	l, c := m.pane.logs.Update(msg)
+	if lm, ok := l.(logs.Model); ok {
+		m.pane.logs = lm
+	}
+	*cmd = c
+}
+
+// splitView Render the pod table with the log preview
+func (m Model) splitView() string {
+	w, h := m.paneArea()
+	title := "logs"
+	if m.pane.streaming.Name != "" {
+		title = crumbOf(m.pane.streaming) + " logs"
+	}
+	if m.pane.want != m.pane.streaming {
+		title += " ..."
+	}
+	if m.pane.err != nil {
+		title += ": " + m.pane.err.Error()
+	}
+	preview := titleStyle.Render(title) + "\n" + m.pane.logs.View()
+	return m.pane.split.Render(m.pod.View(), preview, w, h)
+}
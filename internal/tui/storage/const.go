@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const maxColumnWidth = 50
+const columnPadding = 2
+
+var (
+	titleStyle  = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	tabStyle    = lipgloss.NewStyle().Padding(0, 1)
+	activeStyle = lipgloss.NewStyle().Padding(0, 1).Bold(true).Foreground(theme.Accent).Underline(true)
+	statusStyle = lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Accent)
+	promptStyle = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	panelStyle  = lipgloss.NewStyle().MarginLeft(2).Padding(0, 1).Border(lipgloss.RoundedBorder()).BorderForeground(theme.Accent)
+	helpStyle   = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
+)
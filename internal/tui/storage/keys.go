@@ -0,0 +1,48 @@
+package storage
+
+import "github.com/charmbracelet/bubbles/key"
+
+type KeyMap struct {
+	Switch  key.Binding
+	Detail  key.Binding
+	Resize  key.Binding
+	Refresh key.Binding
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return append([]key.Binding{k.Switch, k.Detail, k.Resize, k.Refresh}, Plugins...)
+
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Switch, k.Detail, k.Resize, k.Refresh},
+	}
+}
+
+var keys = KeyMap{
+	Switch: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "pvc/pv/class"),
+	),
+	Detail: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "details"),
+	),
+	Resize: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "resize"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
+}
+
+// Plugins are the user commands that apply to the view, set by LoadConfig
+var Plugins []key.Binding
+
+// Bindings Get the key map so the user configuration can remap it
+func Bindings() *KeyMap {
+	return &keys
+}
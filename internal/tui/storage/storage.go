@@ -0,0 +1,374 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strconv"
+	"strings"
+)
+
+// Tab Which storage resource is listed
+type Tab uint8
+
+const (
+	Claims Tab = iota
+	Volumes
+	Classes
+)
+
+var tabNames = []string{"PersistentVolumeClaims", "PersistentVolumes", "StorageClasses"}
+
+type Model struct {
+	Namespace string
+	Tab       Tab
+	Claims    table.Model
+	Volumes   table.Model
+	Classes   table.Model
+	Help      help.Model
+	Size      textinput.Model
+	Resizing  bool
+	pvcs      []v1.PersistentVolumeClaim
+	pvs       []v1.PersistentVolume
+	classes   []storagev1.StorageClass
+	detail    string
+	status    string
+	// claimsOnly is set when volumes or classes can't be listed, users often may not read them
+	claimsOnly bool
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Help.Width = msg.Width
+		m.Claims.SetWidth(msg.Width)
+		m.Volumes.SetWidth(msg.Width)
+		m.Classes.SetWidth(msg.Width)
+
+	case tea.KeyMsg:
+		if m.Resizing {
+			return m.updateResize(msg)
+		}
+		pvc, ok := m.selectedClaim()
+		switch keypress := msg.String(); keypress {
+		case "tab":
+			if m.claimsOnly {
+				break
+			}
+			m.Tab = (m.Tab + 1) % Tab(len(tabNames))
+			m.detail = ""
+			m.status = ""
+		case "enter":
+			if m.Tab == Claims && ok {
+				m.detail = m.claimDetail(pvc)
+			}
+		case "x":
+			if m.Tab != Claims || !ok {
+				break
+			}
+			// Without the classes the API server decides whether the claim can grow
+			if !m.claimsOnly && !kubernetes.CanExpand(pvc, m.classes) {
+				m.status = fmt.Sprintf("storage class %s does not allow volume expansion", kubernetes.ColumnHelperStorageClass(pvc.Spec.StorageClassName))
+				break
+			}
+			m.Resizing = true
+			m.Size.SetValue(kubernetes.ColumnHelperCapacity(pvc.Spec.Resources.Requests))
+			m.Size.CursorEnd()
+			cmd = m.Size.Focus()
+		case "r":
+			RefreshStorage(&m)
+		default:
+			t := m.table()
+			*t, cmd = t.Update(msg)
+			m.detail = ""
+		}
+	default:
+		if m.Resizing {
+			m.Size, cmd = m.Size.Update(msg)
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) updateResize(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg.String() {
+	case "esc":
+		m.Resizing = false
+		m.Size.Blur()
+	case "enter":
+		m.Resizing = false
+		m.Size.Blur()
+		pvc, ok := m.selectedClaim()
+		if !ok {
+			break
+		}
+		ctx, cancelFunc := context.WithCancel(kubernetes.Bind(context.Background()))
+		defer cancelFunc()
+		if err := kubernetes.ResizePVC(ctx, pvc, strings.TrimSpace(m.Size.Value())); err != nil {
+			m.status = err.Error()
+			break
+		}
+		m.status = fmt.Sprintf("pvc/%s resize to %s requested", pvc.Name, m.Size.Value())
+		RefreshStorage(&m)
+	default:
+		m.Size, cmd = m.Size.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	var tabs []string
+	names := tabNames
+	if m.claimsOnly {
+		names = tabNames[:Volumes]
+	}
+	for i, n := range names {
+		if Tab(i) == m.Tab {
+			tabs = append(tabs, activeStyle.Render(n))
+		} else {
+			tabs = append(tabs, tabStyle.Render(n))
+		}
+	}
+	b.WriteString("\n" + titleStyle.Render(strings.Join(tabs, "")+fmt.Sprintf(" (%s)", m.Namespace)) + "\n\n")
+	b.WriteString(m.table().View() + "\n")
+	if m.detail != "" {
+		b.WriteString(panelStyle.Render(m.detail) + "\n")
+	}
+	if m.Resizing {
+		b.WriteString(promptStyle.Render("New size: ") + m.Size.View() + "\n")
+	} else if m.status != "" {
+		b.WriteString(statusStyle.Render(m.status) + "\n")
+	}
+	b.WriteString(helpStyle.Render(m.Help.View(keys)))
+	return b.String()
+}
+
+func (m *Model) table() *table.Model {
+	switch m.Tab {
+	case Volumes:
+		return &m.Volumes
+	case Classes:
+		return &m.Classes
+	default:
+		return &m.Claims
+	}
+}
+
+func (m Model) selectedClaim() (v1.PersistentVolumeClaim, bool) {
+	c := m.Claims.Cursor()
+	if c < 0 || c >= len(m.pvcs) {
+		return v1.PersistentVolumeClaim{}, false
+	}
+	return m.pvcs[c], true
+}
+
+// Ref Get the claim or volume under the cursor, storage classes have none
+func (m Model) Ref() (kubernetes.ObjectRef, bool) {
+	switch m.Tab {
+	case Claims:
+		pvc, ok := m.selectedClaim()
+		return kubernetes.ObjectRef{Kind: "PersistentVolumeClaim", Namespace: pvc.Namespace, Name: pvc.Name}, ok
+	case Volumes:
+		c := m.Volumes.Cursor()
+		if c < 0 || c >= len(m.pvs) {
+			return kubernetes.ObjectRef{}, false
+		}
+		return kubernetes.ObjectRef{Kind: "PersistentVolume", Name: m.pvs[c].Name}, true
+	default:
+		return kubernetes.ObjectRef{}, false
+	}
+}
+
+// Select Move the cursor to the named claim or volume
+func (m *Model) Select(kind string, name string) {
+	switch kind {
+	case "PersistentVolume":
+		m.Tab = Volumes
+		for i, pv := range m.pvs {
+			if pv.Name == name {
+				m.Volumes.SetCursor(i)
+			}
+		}
+	default:
+		m.Tab = Claims
+		for i, pvc := range m.pvcs {
+			if pvc.Name == name {
+				m.Claims.SetCursor(i)
+			}
+		}
+	}
+}
+
+// claimDetail Describe who mounts a claim, its volume, and why it is still pending
+func (m Model) claimDetail(pvc v1.PersistentVolumeClaim) string {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	var b strings.Builder
+	b.WriteString("pvc/" + pvc.Name + "\n")
+	pds, err := kubernetes.GetPods(ctx, pvc.Namespace)
+	if err != nil {
+		return err.Error()
+	}
+	var names []string
+	for _, p := range kubernetes.PodsMountingPVC(pds, pvc.Name) {
+		names = append(names, p.Name)
+	}
+	if len(names) == 0 {
+		names = []string{"<none>"}
+	}
+	b.WriteString("Mounted by: " + strings.Join(names, ", ") + "\n")
+	for _, pv := range m.pvs {
+		if pv.Name == pvc.Spec.VolumeName {
+			b.WriteString(fmt.Sprintf("Volume: %s (reclaim %s)\n", pv.Name, pv.Spec.PersistentVolumeReclaimPolicy))
+		}
+	}
+	b.WriteString(fmt.Sprintf("Expansion: %t", kubernetes.CanExpand(pvc, m.classes)))
+
+	if pvc.Status.Phase == v1.ClaimPending {
+		evs, err := kubernetes.GetObjectEvents(ctx, pvc.Namespace, "PersistentVolumeClaim", pvc.Name)
+		if err != nil {
+			return err.Error()
+		}
+		b.WriteString("\nProvisioning events:")
+		if len(evs) == 0 {
+			b.WriteString(" <none>")
+		}
+		for _, e := range evs {
+			b.WriteString(fmt.Sprintf("\n  %s %s %s: %s", kubernetes.ColumnHelperAge(metav1.NewTime(kubernetes.EventTimestamp(e))), e.Type, e.Reason, strings.TrimSpace(e.Message)))
+		}
+	}
+	return b.String()
+}
+
+func RefreshStorage(m *Model) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	pvcs, err := kubernetes.GetPVCs(ctx, m.Namespace)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	// Volumes and classes are cluster wide, a namespaced user still gets the claims
+	pvs, err := kubernetes.GetPVs(ctx)
+	var classes []storagev1.StorageClass
+	if err == nil {
+		classes, err = kubernetes.GetStorageClasses(ctx)
+	}
+	m.claimsOnly = err != nil
+	if m.claimsOnly {
+		m.status = "showing claims only: " + err.Error()
+		m.Tab = Claims
+		pvs, classes = nil, nil
+	}
+	m.pvcs, m.pvs, m.classes = pvcs, pvs, classes
+
+	var rows []table.Row
+	for _, pvc := range pvcs {
+		rows = append(rows, table.Row{
+			pvc.Name,
+			string(pvc.Status.Phase),
+			pvc.Spec.VolumeName,
+			kubernetes.ColumnHelperCapacity(pvc.Status.Capacity),
+			kubernetes.ColumnHelperAccessModes(pvc.Spec.AccessModes),
+			kubernetes.ColumnHelperStorageClass(pvc.Spec.StorageClassName),
+			kubernetes.ColumnHelperAge(pvc.CreationTimestamp),
+		})
+	}
+	setTable(&m.Claims, []table.Column{
+		{Title: "NAME"}, {Title: "STATUS"}, {Title: "VOLUME"}, {Title: "CAPACITY"}, {Title: "ACCESS MODES"}, {Title: "STORAGECLASS"}, {Title: "AGE"},
+	}, rows)
+
+	rows = nil
+	for _, pv := range pvs {
+		rows = append(rows, table.Row{
+			pv.Name,
+			kubernetes.ColumnHelperCapacity(pv.Spec.Capacity),
+			kubernetes.ColumnHelperAccessModes(pv.Spec.AccessModes),
+			string(pv.Spec.PersistentVolumeReclaimPolicy),
+			string(pv.Status.Phase),
+			kubernetes.ColumnHelperClaim(pv),
+			kubernetes.ColumnHelperStorageClass(&pv.Spec.StorageClassName),
+			kubernetes.ColumnHelperAge(pv.CreationTimestamp),
+		})
+	}
+	setTable(&m.Volumes, []table.Column{
+		{Title: "NAME"}, {Title: "CAPACITY"}, {Title: "ACCESS MODES"}, {Title: "RECLAIM POLICY"}, {Title: "STATUS"}, {Title: "CLAIM"}, {Title: "STORAGECLASS"}, {Title: "AGE"},
+	}, rows)
+
+	rows = nil
+	for _, sc := range classes {
+		reclaim, binding := "Delete", "Immediate"
+		if sc.ReclaimPolicy != nil {
+			reclaim = string(*sc.ReclaimPolicy)
+		}
+		if sc.VolumeBindingMode != nil {
+			binding = string(*sc.VolumeBindingMode)
+		}
+		rows = append(rows, table.Row{
+			sc.Name,
+			sc.Provisioner,
+			reclaim,
+			binding,
+			strconv.FormatBool(sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion),
+			kubernetes.ColumnHelperAge(sc.CreationTimestamp),
+		})
+	}
+	setTable(&m.Classes, []table.Column{
+		{Title: "NAME"}, {Title: "PROVISIONER"}, {Title: "RECLAIM POLICY"}, {Title: "BINDING MODE"}, {Title: "EXPANSION"}, {Title: "AGE"},
+	}, rows)
+}
+
+// setTable Size the columns to their content and set the rows
+func setTable(t *table.Model, columns []table.Column, rows []table.Row) {
+	for j := range columns {
+		columns[j].Width = len(columns[j].Title)
+		for _, r := range rows {
+			columns[j].Width = min(max(columns[j].Width, len(r[j])), config.ColumnWidth(maxColumnWidth))
+		}
+		columns[j].Width += columnPadding
+	}
+	t.SetRows(nil)
+	t.SetColumns(columns)
+	t.SetRows(rows)
+}
+
+func newTable() table.Model {
+	t := table.New(
+		table.WithFocused(true),
+	)
+
+	t.SetStyles(theme.TableStyles())
+	return t
+}
+
+func New(namespace string) Model {
+	ti := textinput.New()
+	ti.Placeholder = "10Gi"
+	m := Model{
+		Namespace: namespace,
+		Claims:    newTable(),
+		Volumes:   newTable(),
+		Classes:   newTable(),
+		Help:      help.New(),
+		Size:      ti,
+	}
+	RefreshStorage(&m)
+	return m
+}
@@ -0,0 +1,245 @@
+package tui
+
+import (
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/logs"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/pods"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"reflect"
+	"strings"
+)
+
+// maxTabs caps the tabs of a session, each one keeps its watches open
+const maxTabs = 6
+
+var (
+	tabStyle       = lipgloss.NewStyle().Padding(0, 1)
+	activeTabStyle = lipgloss.NewStyle().Padding(0, 1).Bold(true).Foreground(theme.BadgeForeground)
+)
+
+// Tabs is a session with one Model per tab, each with its own context, namespace, watches and view stack
+type Tabs struct {
+	tabs   []Model
+	active int
+	nextID int
+	width  int
+	height int
+}
+
+// tabMsg carries a message to the tab it comes from, whichever tab is shown
+type tabMsg struct {
+	id  int
+	msg tea.Msg
+}
+
+// newTabMsg asks for a tab on a kube context, the one of the current tab when empty
+type newTabMsg struct {
+	context string
+}
+
+// teaPackage is where the messages for the Bubble Tea runtime itself come from, like quit
+var teaPackage = reflect.TypeOf(tea.QuitMsg{}).PkgPath()
+
+// NewTabs Start a session with a tab on the current context of the kubeconfig
+func NewTabs() Tabs {
+	return Tabs{tabs: []Model{newModel("")}, nextID: 1}
+}
+
+func (t Tabs) Init() tea.Cmd {
+	return tag(t.tabs[0].id, t.tabs[0].Init())
+}
+
+// tag Mark the messages of the commands of a tab so they reach it once another tab is shown
+func tag(id int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		switch msg := cmd().(type) {
+		case nil:
+			return nil
+		case tea.BatchMsg:
+			tagged := make(tea.BatchMsg, len(msg))
+			for i, c := range msg {
+				tagged[i] = tag(id, c)
+			}
+			return tagged
+		default:
+			if reflect.TypeOf(msg).PkgPath() == teaPackage {
+				// Quit, exec and the like are for the runtime, what they report back goes to the tab shown
+				return msg
+			}
+			return tabMsg{id: id, msg: msg}
+		}
+	}
+}
+
+func (t Tabs) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tabMsg:
+		if nt, ok := msg.msg.(newTabMsg); ok {
+			return t, t.open(nt.context)
+		}
+		for i := range t.tabs {
+			if t.tabs[i].id == msg.id {
+				return t, t.deliver(i, msg.msg)
+			}
+		}
+		// The tab was closed
+		return t, nil
+	case tea.WindowSizeMsg:
+		t.width, t.height = msg.Width, msg.Height
+		return t, t.resize()
+	case tea.KeyMsg:
+		if !t.tabs[t.active].typing() {
+			if cmd, ok := t.switchKey(msg); ok {
+				return t, cmd
+			}
+		}
+	}
+	return t, t.deliver(t.active, msg)
+}
+
+// deliver Update a tab, its calls go to its own cluster
+func (t *Tabs) deliver(i int, msg tea.Msg) tea.Cmd {
+	kubernetes.Use(t.tabs[i].context.SelectedContext.Name)
+	m, cmd := t.tabs[i].Update(msg)
+	if tm, ok := m.(Model); ok {
+		t.tabs[i] = tm
+	}
+	kubernetes.Use(t.tabs[t.active].context.SelectedContext.Name)
+	return tag(t.tabs[i].id, cmd)
+}
+
+// switchKey Handle the keys that open, close and switch tabs
+func (t *Tabs) switchKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, globalKeys.NewTab):
+		return t.open(""), true
+	case key.Matches(msg, globalKeys.CloseTab):
+		return t.close(), true
+	case key.Matches(msg, globalKeys.NextTab):
+		return t.show((t.active + 1) % len(t.tabs)), true
+	case key.Matches(msg, globalKeys.PrevTab):
+		return t.show((t.active + len(t.tabs) - 1) % len(t.tabs)), true
+	default:
+		return nil, false
+	}
+}
+
+// open Add a tab on a kube context and show it
+func (t *Tabs) open(context string) tea.Cmd {
+	if len(t.tabs) >= maxTabs {
+		t.tabs[t.active].notice = fmt.Sprintf("at most %d tabs, close one with %s", maxTabs, globalKeys.CloseTab.Help().Key)
+		return nil
+	}
+	if context == "" {
+		context = t.tabs[t.active].context.SelectedContext.Name
+	}
+	t.tabs[t.active].background = true
+	m := newModel(context)
+	m.id = t.nextID
+	t.nextID++
+	t.tabs = append(t.tabs, m)
+	t.active = len(t.tabs) - 1
+	return tea.Batch(tag(m.id, m.Init()), t.resize())
+}
+
+// close Stop the watches of the tab shown and show the one before, the last tab stays
+func (t *Tabs) close() tea.Cmd {
+	if len(t.tabs) == 1 {
+		return nil
+	}
+	t.tabs[t.active].stop()
+	t.tabs = append(t.tabs[:t.active], t.tabs[t.active+1:]...)
+	i := max(t.active-1, 0)
+	t.active = -1
+	return tea.Batch(t.show(i), t.resize())
+}
+
+// show Bring a tab to the front, what it put off while hidden happens now
+func (t *Tabs) show(i int) tea.Cmd {
+	if i == t.active {
+		return nil
+	}
+	if t.active >= 0 {
+		t.tabs[t.active].background = true
+	}
+	t.active = i
+	m := &t.tabs[i]
+	kubernetes.Use(m.context.SelectedContext.Name)
+	m.enableKeys()
+	return tag(m.id, m.resume())
+}
+
+// resize Give every tab the screen under the tab bar
+func (t *Tabs) resize() tea.Cmd {
+	if t.width == 0 {
+		return nil
+	}
+	size := tea.WindowSizeMsg{Width: t.width, Height: t.height - lipgloss.Height(t.bar())}
+	var cmds []tea.Cmd
+	for i := range t.tabs {
+		cmds = append(cmds, t.deliver(i, size))
+	}
+	return tea.Batch(cmds...)
+}
+
+// bar Render a tab per context in the color of its profile, nothing while there is a single tab
+func (t Tabs) bar() string {
+	if len(t.tabs) < 2 {
+		return ""
+	}
+	var b strings.Builder
+	for i, m := range t.tabs {
+		name := fmt.Sprintf("%d %s", i+1, m.context.SelectedContext.Name)
+		if m.profile.Label != "" {
+			name += " " + m.profile.Label
+		}
+		color := theme.Accent
+		if m.profile.Color != "" {
+			color = lipgloss.Color(m.profile.Color)
+		}
+		if i == t.active {
+			b.WriteString(activeTabStyle.Background(color).Render(name))
+		} else {
+			b.WriteString(tabStyle.Foreground(color).Render(name))
+		}
+	}
+	return b.String() + "\n"
+}
+
+func (t Tabs) View() string {
+	return t.bar() + t.tabs[t.active].View()
+}
+
+// resume Catch up on what a tab put off while hidden
+func (m *Model) resume() tea.Cmd {
+	m.background = false
+	var cmd tea.Cmd
+	if m.stale {
+		m.stale = false
+		pods.RefreshPods(&m.pod, false)
+	}
+	for _, tick := range m.metricsDue {
+		cmd = tea.Batch(cmd, m.tickMetrics(tick))
+	}
+	m.metricsDue = nil
+	return cmd
+}
+
+// stop Close the watches and streams of a tab
+func (m *Model) stop() {
+	m.watch.Stop()
+	m.eventWatch.Stop()
+	if m.log.Ctx != nil {
+		m.log.Stop()
+	}
+	m.stopPreview()
+	m.node.StopDrain()
+	m.log = logs.Model{}
+}
@@ -0,0 +1,33 @@
+// Package theme holds the colors of the configured skin, styles built at package
+// initialization pick them up since the configuration is loaded before
+package theme
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	Accent             = lipgloss.Color(config.Current.Theme.Accent)
+	Error              = lipgloss.Color(config.Current.Theme.Error)
+	Warning            = lipgloss.Color(config.Current.Theme.Warning)
+	OK                 = lipgloss.Color(config.Current.Theme.OK)
+	SelectedBackground = lipgloss.Color(config.Current.Theme.SelectedBackground)
+	BadgeForeground    = lipgloss.Color(config.Current.Theme.BadgeForeground)
+)
+
+// TableStyles Get the header and selected row styles every table uses
+func TableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(Accent).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(Accent).
+		Background(SelectedBackground).
+		Bold(false)
+	return s
+}
@@ -0,0 +1,17 @@
+package usage
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Thresholds in percent of request, limit or allocatable
+const (
+	warnPercent     = 70
+	criticalPercent = 90
+)
+
+var (
+	warnStyle     = lipgloss.NewStyle().Foreground(theme.Warning)
+	criticalStyle = lipgloss.NewStyle().Foreground(theme.Error)
+)
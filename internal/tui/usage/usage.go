@@ -0,0 +1,62 @@
+package usage
+
+import (
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/charmbracelet/bubbles/table"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Unavailable is shown when there is no usage to show
+const Unavailable = "n/a"
+
+// Cell Render usage with its percent of request and limit, colored by threshold
+func Cell(name v1.ResourceName, used v1.ResourceList, requests v1.ResourceList, limits v1.ResourceList) string {
+	u, ok := used[name]
+	if !ok {
+		return Unavailable
+	}
+	return fmt.Sprintf("%s %s/%s",
+		kubernetes.FormatQuantity(name, u),
+		Percent(name, used, requests),
+		Percent(name, used, limits))
+}
+
+// Percent Render used as a percent of total, colored by threshold
+func Percent(name v1.ResourceName, used v1.ResourceList, total v1.ResourceList) string {
+	u := used[name]
+	t, ok := total[name]
+	if !ok || t.MilliValue() == 0 {
+		return "-"
+	}
+	pct := u.MilliValue() * 100 / t.MilliValue()
+	s := fmt.Sprintf("%d%%", pct)
+	switch {
+	case pct >= criticalPercent:
+		return criticalStyle.Render(s)
+	case pct >= warnPercent:
+		return warnStyle.Render(s)
+	default:
+		return s
+	}
+}
+
+// AddColumns Append a column per title, cells gives the cells of a row. The cells may be colored
+// so the columns are sized on their raw length to keep the table from cutting escape codes
+func AddColumns(columns []table.Column, rows []table.Row, padding int, titles []string, cells func(row int) []string) []table.Column {
+	added := make([]table.Column, len(titles))
+	for j, t := range titles {
+		added[j] = table.Column{Title: t, Width: len(t)}
+	}
+	for i := range rows {
+		cs := cells(i)
+		rows[i] = append(rows[i], cs...)
+		for j, c := range cs {
+			added[j].Width = max(added[j].Width, len(c))
+		}
+	}
+	for j := range added {
+		added[j].Width += padding
+	}
+	return append(columns, added...)
+}
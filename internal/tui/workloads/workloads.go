@@ -0,0 +1,159 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"github.com/OliveiraNt/k8s-manager/internal/config"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/xray"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"strconv"
+	"strings"
+)
+
+// Model lists the deployments, stateful sets or daemon sets of a namespace
+type Model struct {
+	Namespace string
+	// Kind is one of kubernetes.WorkloadKinds
+	Kind      string
+	Workloads table.Model
+	Help      help.Model
+	items     []kubernetes.Workload
+	status    string
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Help.Width = msg.Width
+		m.Workloads.SetWidth(msg.Width)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab":
+			m.Kind = nextKind(m.Kind)
+			RefreshWorkloads(&m)
+			m.Workloads.GotoTop()
+		case "enter":
+			if w, ok := m.selected(); ok {
+				open := xray.OpenMsg{Kind: m.Kind, Namespace: w.Namespace, Name: w.Name}
+				cmd = func() tea.Msg { return open }
+			}
+		case "r":
+			RefreshWorkloads(&m)
+		default:
+			m.Workloads, cmd = m.Workloads.Update(msg)
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	var tabs strings.Builder
+	for _, k := range kubernetes.WorkloadKinds {
+		style := tabStyle
+		if k == m.Kind {
+			style = activeStyle
+		}
+		tabs.WriteString(style.Render(k + "s"))
+	}
+	b.WriteString("\n" + titleStyle.Render(tabs.String()+fmt.Sprintf(" (%s)", m.Namespace)) + "\n\n")
+	b.WriteString(m.Workloads.View() + "\n")
+	if m.status != "" {
+		b.WriteString(statusStyle.Render(m.status) + "\n")
+	}
+	b.WriteString(helpStyle.Render(m.Help.View(keys)))
+	return b.String()
+}
+
+// nextKind Get the kind the next tab shows
+func nextKind(kind string) string {
+	for i, k := range kubernetes.WorkloadKinds {
+		if k == kind {
+			return kubernetes.WorkloadKinds[(i+1)%len(kubernetes.WorkloadKinds)]
+		}
+	}
+	return kubernetes.WorkloadKinds[0]
+}
+
+func (m Model) selected() (kubernetes.Workload, bool) {
+	c := m.Workloads.Cursor()
+	if c < 0 || c >= len(m.items) {
+		return kubernetes.Workload{}, false
+	}
+	return m.items[c], true
+}
+
+// Ref Get the workload under the cursor
+func (m Model) Ref() (kubernetes.ObjectRef, bool) {
+	w, ok := m.selected()
+	return kubernetes.ObjectRef{Kind: m.Kind, Namespace: w.Namespace, Name: w.Name}, ok
+}
+
+func RefreshWorkloads(m *Model) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	// A failed list empties the table, the rows may be of another kind
+	ws, err := kubernetes.GetWorkloads(ctx, m.Kind, m.Namespace)
+	m.status = ""
+	if err != nil {
+		m.status = err.Error()
+	}
+	m.items = ws
+
+	columns := []table.Column{{Title: "NAME"}, {Title: "READY"}, {Title: "UP-TO-DATE"}, {Title: "AVAILABLE"}, {Title: "AGE"}}
+	if m.Namespace == "" {
+		columns = append([]table.Column{{Title: "NAMESPACE"}}, columns...)
+	}
+	var rows []table.Row
+	for _, w := range ws {
+		r := table.Row{
+			w.Name,
+			kubernetes.ColumnHelperWorkloadReady(w),
+			strconv.Itoa(int(w.UpToDate)),
+			strconv.Itoa(int(w.Available)),
+			kubernetes.ColumnHelperAge(w.Created),
+		}
+		if m.Namespace == "" {
+			r = append(table.Row{w.Namespace}, r...)
+		}
+		rows = append(rows, r)
+	}
+	for j := range columns {
+		columns[j].Width = len(columns[j].Title)
+		for _, r := range rows {
+			columns[j].Width = min(max(columns[j].Width, len(r[j])), config.ColumnWidth(maxColumnWidth))
+		}
+		columns[j].Width += columnPadding
+	}
+	m.Workloads.SetRows(nil)
+	m.Workloads.SetColumns(columns)
+	m.Workloads.SetRows(rows)
+}
+
+// New List the workloads of a kind, one of kubernetes.WorkloadKinds
+func New(kind string, namespace string) Model {
+	t := table.New(
+		table.WithFocused(true),
+	)
+
+	t.SetStyles(theme.TableStyles())
+
+	m := Model{
+		Namespace: namespace,
+		Kind:      kind,
+		Workloads: t,
+		Help:      help.New(),
+	}
+	RefreshWorkloads(&m)
+	return m
+}
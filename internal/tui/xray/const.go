@@ -0,0 +1,27 @@
+package xray
+
+import (
+	"github.com/OliveiraNt/k8s-manager/internal/tui/theme"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// chromeHeight is the number of lines around the tree: title, chain and help
+const chromeHeight = 9
+
+const indent = "  "
+
+var (
+	titleStyle    = lipgloss.NewStyle().MarginLeft(2).Bold(true)
+	chainStyle    = lipgloss.NewStyle().MarginLeft(2).Faint(true)
+	statusStyle   = lipgloss.NewStyle().MarginLeft(2).Foreground(theme.Accent)
+	selectedStyle = lipgloss.NewStyle().Foreground(theme.Accent)
+	detailStyle   = lipgloss.NewStyle().Faint(true)
+	helpStyle     = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
+
+	healthStyles = []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(theme.OK),
+		lipgloss.NewStyle().Foreground(theme.Warning),
+		lipgloss.NewStyle().Foreground(theme.Error),
+	}
+)
@@ -0,0 +1,48 @@
+package xray
+
+import "github.com/charmbracelet/bubbles/key"
+
+type KeyMap struct {
+	Toggle   key.Binding
+	Collapse key.Binding
+	Go       key.Binding
+	Refresh  key.Binding
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return append([]key.Binding{k.Toggle, k.Collapse, k.Go, k.Refresh}, Plugins...)
+
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Toggle, k.Collapse, k.Go, k.Refresh},
+	}
+}
+
+var keys = KeyMap{
+	Toggle: key.NewBinding(
+		key.WithKeys("enter", " ", "right", "l"),
+		key.WithHelp("enter", "expand"),
+	),
+	Collapse: key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←", "collapse"),
+	),
+	Go: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "go to"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
+}
+
+// Plugins are the user commands that apply to the view, set by LoadConfig
+var Plugins []key.Binding
+
+// Bindings Get the key map so the user configuration can remap it
+func Bindings() *KeyMap {
+	return &keys
+}
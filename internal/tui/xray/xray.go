@@ -0,0 +1,271 @@
+package xray
+
+import (
+	"context"
+	"github.com/OliveiraNt/k8s-manager/internal/kubernetes"
+	"github.com/OliveiraNt/k8s-manager/internal/tui/events"
+	"github.com/charmbracelet/bubbles/help"
+	tea "github.com/charmbracelet/bubbletea"
+	v1 "k8s.io/api/core/v1"
+	"strings"
+)
+
+type Model struct {
+	Help     help.Model
+	root     *kubernetes.XrayNode
+	chain    []kubernetes.ObjectRef
+	expanded map[*kubernetes.XrayNode]bool
+	lines    []line
+	cursor   int
+	height   int
+	err      error
+}
+
+// OpenMsg asks to show the tree of a workload
+type OpenMsg struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// line is a visible node of the tree
+type line struct {
+	node  *kubernetes.XrayNode
+	depth int
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Help.Width = msg.Width
+		m.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			m.cursor = max(m.cursor-1, 0)
+		case "down", "j":
+			m.cursor = min(m.cursor+1, len(m.lines)-1)
+		case "enter", " ", "right", "l":
+			if n, ok := m.Selected(); ok && len(n.Children) > 0 {
+				m.expanded[n] = !m.expanded[n]
+				m.flatten()
+			}
+		case "left", "h":
+			m.collapse()
+		case "g":
+			if n, ok := m.Selected(); ok {
+				ref := n.ObjectRef
+				cmd = func() tea.Msg { return events.JumpMsg{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name} }
+			}
+		case "r":
+			if m.root != nil {
+				focus, _ := m.Selected()
+				m.load(m.root.ObjectRef, refOf(focus))
+			}
+		default:
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString("\n" + titleStyle.Render("XRay") + "\n")
+	if len(m.chain) > 0 {
+		refs := make([]string, len(m.chain))
+		for i, r := range m.chain {
+			refs[i] = r.String()
+		}
+		b.WriteString(chainStyle.Render("owned by "+strings.Join(refs, " ← ")) + "\n")
+	}
+	b.WriteString("\n")
+	if m.err != nil {
+		b.WriteString(statusStyle.Render(m.err.Error()) + "\n")
+	}
+
+	// Scroll so the cursor stays on screen
+	rows := max(m.height-chromeHeight, 5)
+	start := max(0, m.cursor-rows+1)
+	for i := start; i < len(m.lines) && i < start+rows; i++ {
+		b.WriteString(m.render(i) + "\n")
+	}
+	b.WriteString(helpStyle.Render(m.Help.View(keys)))
+	return b.String()
+}
+
+// render Draw one tree line: fold marker, health dot, kind/name and status
+func (m Model) render(i int) string {
+	l := m.lines[i]
+	marker := "  "
+	if len(l.node.Children) > 0 {
+		marker = "▸ "
+		if m.expanded[l.node] {
+			marker = "▾ "
+		}
+	}
+	name := l.node.ObjectRef.String()
+	if i == m.cursor {
+		name = selectedStyle.Render(name)
+	}
+	dot := healthStyles[l.node.Health].Render("●")
+	return indent + strings.Repeat(indent, l.depth) + marker + dot + " " + name + " " + detailStyle.Render(l.node.Status)
+}
+
+// Selected Get the node under the cursor
+func (m Model) Selected() (*kubernetes.XrayNode, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.lines) {
+		return nil, false
+	}
+	return m.lines[m.cursor].node, true
+}
+
+// collapse Fold the node under the cursor, or move to its parent when already folded
+func (m *Model) collapse() {
+	n, ok := m.Selected()
+	if !ok {
+		return
+	}
+	if m.expanded[n] {
+		m.expanded[n] = false
+		m.flatten()
+		return
+	}
+	depth := m.lines[m.cursor].depth
+	for i := m.cursor - 1; i >= 0; i-- {
+		if m.lines[i].depth < depth {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// flatten Rebuild the visible lines from the expanded nodes, keeping the cursor on its node
+func (m *Model) flatten() {
+	focus, hadFocus := m.Selected()
+	m.lines = nil
+	var walk func(n *kubernetes.XrayNode, depth int)
+	walk = func(n *kubernetes.XrayNode, depth int) {
+		m.lines = append(m.lines, line{node: n, depth: depth})
+		if !m.expanded[n] {
+			return
+		}
+		for _, c := range n.Children {
+			walk(c, depth+1)
+		}
+	}
+	if m.root != nil {
+		walk(m.root, 0)
+	}
+	m.cursor = min(m.cursor, max(len(m.lines)-1, 0))
+	if hadFocus {
+		m.focus(func(n *kubernetes.XrayNode) bool { return n == focus })
+	}
+}
+
+// focus Put the cursor on the first visible node matching
+func (m *Model) focus(match func(n *kubernetes.XrayNode) bool) {
+	for i, l := range m.lines {
+		if match(l.node) {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// expandTo Expand every node on the path to the nodes matching the reference
+func (m *Model) expandTo(n *kubernetes.XrayNode, ref kubernetes.ObjectRef) bool {
+	if n.ObjectRef == ref {
+		return true
+	}
+	found := false
+	for _, c := range n.Children {
+		if m.expandTo(c, ref) {
+			found = true
+		}
+	}
+	if found {
+		m.expanded[n] = true
+	}
+	return found
+}
+
+// Root Get the object at the top of the tree, false when it could not be loaded
+func (m Model) Root() (kubernetes.ObjectRef, bool) {
+	if m.root == nil {
+		return kubernetes.ObjectRef{}, false
+	}
+	return m.root.ObjectRef, true
+}
+
+// load Build the tree of root, opened down to focus
+func (m *Model) load(root kubernetes.ObjectRef, focus kubernetes.ObjectRef) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	n, err := kubernetes.Xray(ctx, root)
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.err = nil
+	m.root = n
+	m.lines = nil
+	m.expanded = map[*kubernetes.XrayNode]bool{n: true}
+	m.expandTo(n, focus)
+	m.flatten()
+	m.focus(func(n *kubernetes.XrayNode) bool { return n.ObjectRef == focus })
+}
+
+func refOf(n *kubernetes.XrayNode) kubernetes.ObjectRef {
+	if n == nil {
+		return kubernetes.ObjectRef{}
+	}
+	return n.ObjectRef
+}
+
+// NewForPod Show the tree of the top owner of a pod, opened down to the pod
+func NewForPod(p *v1.Pod, width int, height int) Model {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	m := newModel(width, height)
+	pod := kubernetes.ObjectRef{Kind: "Pod", Namespace: p.Namespace, Name: p.Name}
+	root := pod
+	m.chain, m.err = kubernetes.OwnerChain(ctx, p.Namespace, p.OwnerReferences)
+	if m.err != nil {
+		return m
+	}
+	if len(m.chain) > 0 {
+		root = m.chain[len(m.chain)-1]
+	}
+	m.load(root, pod)
+	// Owners we can't draw, like custom resources, fall back to the nearest one we can
+	for i := len(m.chain) - 2; m.err != nil && i >= -1; i-- {
+		root = pod
+		if i >= 0 {
+			root = m.chain[i]
+		}
+		m.load(root, pod)
+	}
+	return m
+}
+
+// New Show the tree of a workload
+func New(kind string, namespace string, name string, width int, height int) Model {
+	m := newModel(width, height)
+	m.load(kubernetes.ObjectRef{Kind: kind, Namespace: namespace, Name: name}, kubernetes.ObjectRef{})
+	return m
+}
+
+func newModel(width int, height int) Model {
+	h := help.New()
+	h.Width = width
+	return Model{
+		Help:     h,
+		expanded: map[*kubernetes.XrayNode]bool{},
+		height:   height,
+	}
+}